@@ -0,0 +1,266 @@
+// This file implements punto banco baccarat. The player bets on the Player hand, the Banker hand or a tie, and both
+// hands are dealt by the fixed drawing rules from a multi-deck shoe kept for each channel.
+package main
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Baccarat bets, which are also the possible results of a coup
+const (
+	BaccaratPlayer = "player"
+	BaccaratBanker = "banker"
+	BaccaratTie    = "tie"
+)
+
+// BaccaratCommission is the percentage taken from winning Banker bets, which otherwise have the edge
+const BaccaratCommission = 5
+
+// BaccaratTiePays is the chips won for each chip on a winning tie bet
+const BaccaratTiePays = 8
+
+// BeadRoadRows is the number of rows in the bead road, which fills top to bottom then left to right
+const BeadRoadRows = 6
+
+// BeadRoadColumns is the number of columns of recent results shown in the bead road
+const BeadRoadColumns = 12
+
+// BaccaratHand The cards in a Player or Banker hand
+type BaccaratHand []Card
+
+// Value Returns the value of the hand, which is the last digit of the total. Tens and pictures count as zero.
+func (h BaccaratHand) Value() int {
+	total := 0
+	for _, card := range h {
+		total += card.Rank.Value()
+	}
+	return total % 10
+}
+
+// Implementing the stringer interface for BaccaratHand
+func (h BaccaratHand) String() string {
+	return RenderCards(h)
+}
+
+// BankerDraws Returns whether the Banker draws a third card. This is the tableau, which depends on the Banker's total
+// and the Player's third card. If the Player stood, the Banker draws on 5 or less, like the Player.
+func BankerDraws(banker int, playerThird *Card) bool {
+
+	if playerThird == nil {
+		return banker <= 5
+	}
+
+	third := playerThird.Rank.Value() % 10
+
+	switch banker {
+	case 0, 1, 2:
+		return true
+	case 3:
+		return third != 8
+	case 4:
+		return third >= 2 && third <= 7
+	case 5:
+		return third >= 4 && third <= 7
+	case 6:
+		return third == 6 || third == 7
+	default:
+		return false
+	}
+
+}
+
+// BaccaratCoup The two hands dealt in a round of baccarat, and who won
+type BaccaratCoup struct {
+	Player BaccaratHand
+	Banker BaccaratHand
+	Result string
+}
+
+// BaccaratShoe The shoe and bead road for a channel
+type BaccaratShoe struct {
+	mu    sync.Mutex
+	Shoe  Deck
+	Decks int
+	// Road is the results of the coups dealt since the shoe was shuffled, oldest first
+	Road []string
+}
+
+var (
+	// BaccaratShoesMap baccarat shoes, by channel ID
+	BaccaratShoesMap = make(map[string]*BaccaratShoe)
+	baccaratShoesMu  sync.Mutex
+)
+
+// FindBaccaratShoe Returns the shoe for the channel, making one if there isn't one yet
+func FindBaccaratShoe(channelID string) *BaccaratShoe {
+
+	baccaratShoesMu.Lock()
+	defer baccaratShoesMu.Unlock()
+
+	shoe, ok := BaccaratShoesMap[channelID]
+	if !ok {
+		shoe = &BaccaratShoe{Decks: Config.BaccaratDecks}
+		shoe.shuffle()
+		BaccaratShoesMap[channelID] = shoe
+	}
+
+	return shoe
+
+}
+
+// shuffle Starts a new shoe, clearing the bead road
+func (b *BaccaratShoe) shuffle() {
+	b.Shoe = NewShoe(b.Decks)
+	b.Road = nil
+}
+
+// Deal Deals a coup, following the drawing rules. The shoe is reshuffled once the cut card is reached.
+func (b *BaccaratShoe) Deal() BaccaratCoup {
+
+	if float64(len(b.Shoe)) <= float64(b.Decks*52)*(1-Penetration) {
+		b.shuffle()
+	}
+
+	coup := BaccaratCoup{}
+	coup.Player = BaccaratHand{b.Shoe.DealCard(), b.Shoe.DealCard()}
+	coup.Banker = BaccaratHand{b.Shoe.DealCard(), b.Shoe.DealCard()}
+
+	// A natural 8 or 9 for either hand means neither draws
+	if coup.Player.Value() < 8 && coup.Banker.Value() < 8 {
+		var playerThird *Card
+		if coup.Player.Value() <= 5 {
+			card := b.Shoe.DealCard()
+			coup.Player = append(coup.Player, card)
+			playerThird = &card
+		}
+		if BankerDraws(coup.Banker.Value(), playerThird) {
+			coup.Banker = append(coup.Banker, b.Shoe.DealCard())
+		}
+	}
+
+	switch {
+	case coup.Player.Value() > coup.Banker.Value():
+		coup.Result = BaccaratPlayer
+	case coup.Banker.Value() > coup.Player.Value():
+		coup.Result = BaccaratBanker
+	default:
+		coup.Result = BaccaratTie
+	}
+
+	b.Road = append(b.Road, coup.Result)
+
+	return coup
+
+}
+
+// BeadRoad Returns the recent results as a grid of coloured circles, filled top to bottom then left to right.
+// Blue is a Player win, red is a Banker win and green is a tie.
+func (b *BaccaratShoe) BeadRoad() string {
+
+	beads := map[string]string{BaccaratPlayer: "🔵", BaccaratBanker: "🔴", BaccaratTie: "🟢"}
+
+	// Only showing the most recent columns, starting from the top of a column
+	road := b.Road
+	if maxBeads := BeadRoadRows * BeadRoadColumns; len(road) > maxBeads {
+		start := (len(road) - maxBeads + BeadRoadRows - 1) / BeadRoadRows * BeadRoadRows
+		road = road[start:]
+	}
+
+	var sb strings.Builder
+	for row := 0; row < BeadRoadRows; row++ {
+		for column := 0; column*BeadRoadRows < len(road); column++ {
+			if index := column*BeadRoadRows + row; index < len(road) {
+				sb.WriteString(beads[road[index]])
+			} else {
+				sb.WriteString("⚫")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+
+}
+
+// BaccaratNet Returns the net chips won or lost by a bet on the coup. Player and Banker bets are returned on a tie,
+// and winning Banker bets pay less the commission, rounded down.
+func BaccaratNet(bet string, wager int, result string) int {
+
+	switch {
+	case bet == result && bet == BaccaratTie:
+		return wager * BaccaratTiePays
+	case bet == result && bet == BaccaratBanker:
+		return wager * (100 - BaccaratCommission) / 100
+	case bet == result:
+		return wager
+	case result == BaccaratTie:
+		return 0
+	default:
+		return -wager
+	}
+
+}
+
+// BaccaratCommand handles the /baccarat command, dealing a coup from the channel's shoe and settling the bet
+func BaccaratCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	// Getting options and storing in map
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	bet := optionMap["bet"].StringValue()
+	wager := int(optionMap["wager"].IntValue())
+
+	locale := LocaleFor(i)
+	player := dba.FindPlayer(i.Member.User.Username)
+	if player.Chips < wager {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", player.Chips))
+		return
+	}
+
+	// Holding the wager in escrow while the coup is dealt, so it's settled against the wager rather than the chips the
+	// player had when the command came in
+	escrowID, err := dba.EscrowChips(&player, wager)
+	if err != nil {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", dba.GetChipTotal(player.Username)))
+		return
+	}
+
+	shoe := FindBaccaratShoe(i.ChannelID)
+	shoe.mu.Lock()
+	coup := shoe.Deal()
+	road := shoe.BeadRoad()
+	shoe.mu.Unlock()
+
+	var sb strings.Builder
+	sb.WriteString(T(locale, "baccarat.bets", player.Username, wager, T(locale, "baccarat."+bet)))
+	sb.WriteString(T(locale, "baccarat.hand", T(locale, "baccarat.player"), coup.Player, coup.Player.Value()))
+	sb.WriteString(T(locale, "baccarat.hand", T(locale, "baccarat.banker"), coup.Banker, coup.Banker.Value()) + "\n")
+	if coup.Result == BaccaratTie {
+		sb.WriteString(T(locale, "baccarat.tie_result"))
+	} else {
+		sb.WriteString(T(locale, "baccarat.wins", T(locale, "baccarat."+coup.Result)))
+	}
+	settled, _ := dba.SettleEscrow(escrowID, &player, locale, BaccaratNet(bet, wager, coup.Result))
+	sb.WriteString(settled)
+
+	sb.WriteString(T(locale, "baccarat.bead_road") + road)
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: sb.String(),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
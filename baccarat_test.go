@@ -0,0 +1,103 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBankerDraws(t *testing.T) {
+
+	card := func(rank Rank) *Card { return &Card{Rank: rank, Suit: Clubs} }
+
+	tests := []struct {
+		name        string
+		banker      int
+		playerThird *Card
+		want        bool
+	}{
+		{"player stood, banker 5", 5, nil, true},
+		{"player stood, banker 6", 6, nil, false},
+		{"banker 2 on an 8", 2, card(Eight), true},
+		{"banker 3 on a 9", 3, card(Nine), true},
+		{"banker 3 on an 8", 3, card(Eight), false},
+		{"banker 4 on an ace", 4, card(Ace), false},
+		{"banker 4 on a 2", 4, card(Two), true},
+		{"banker 4 on a 7", 4, card(Seven), true},
+		{"banker 4 on a 10", 4, card(Ten), false},
+		{"banker 5 on a 3", 5, card(Three), false},
+		{"banker 5 on a 4", 5, card(Four), true},
+		{"banker 5 on an 8", 5, card(Eight), false},
+		{"banker 6 on a 5", 6, card(Five), false},
+		{"banker 6 on a 6", 6, card(Six), true},
+		{"banker 6 on a king", 6, card(King), false},
+		{"banker 7 on a 7", 7, card(Seven), false},
+	}
+
+	for _, test := range tests {
+		if got := BankerDraws(test.banker, test.playerThird); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+
+}
+
+func TestBaccaratDeal(t *testing.T) {
+
+	// stacked Returns a shoe that deals the ranks in the order given, two to the Player, two to the Banker, then any
+	// third cards
+	stacked := func(ranks ...Rank) *BaccaratShoe {
+		var shoe Deck
+		for _, rank := range ranks {
+			shoe = append(shoe, Card{Rank: rank, Suit: Spades})
+		}
+		slices.Reverse(shoe)
+		return &BaccaratShoe{Shoe: shoe}
+	}
+
+	tests := []struct {
+		name        string
+		shoe        *BaccaratShoe
+		player      int
+		banker      int
+		playerCards int
+		bankerCards int
+		wantResult  string
+	}{
+		{"player natural", stacked(Nine, King, Three, Four), 9, 7, 2, 2, BaccaratPlayer},
+		{"banker 3 stands on an 8", stacked(Two, Three, Ten, Three, Eight), 3, 3, 3, 2, BaccaratTie},
+		{"banker draws when the player stands", stacked(Four, Two, Two, Three, Four), 6, 9, 2, 3, BaccaratBanker},
+		{"banker 6 draws on a 6", stacked(Ace, Four, Three, Three, Six, Two), 1, 8, 3, 3, BaccaratBanker},
+	}
+
+	for _, test := range tests {
+		coup := test.shoe.Deal()
+		if coup.Player.Value() != test.player || coup.Banker.Value() != test.banker ||
+			len(coup.Player) != test.playerCards || len(coup.Banker) != test.bankerCards || coup.Result != test.wantResult {
+			t.Errorf("%s: got player %s, banker %s, %s", test.name, coup.Player, coup.Banker, coup.Result)
+		}
+	}
+
+}
+
+func TestBaccaratNet(t *testing.T) {
+
+	tests := []struct {
+		bet    string
+		result string
+		want   int
+	}{
+		{BaccaratPlayer, BaccaratPlayer, 100},
+		{BaccaratBanker, BaccaratBanker, 95},
+		{BaccaratTie, BaccaratTie, 800},
+		{BaccaratPlayer, BaccaratTie, 0},
+		{BaccaratBanker, BaccaratPlayer, -100},
+		{BaccaratTie, BaccaratBanker, -100},
+	}
+
+	for _, test := range tests {
+		if got := BaccaratNet(test.bet, 100, test.result); got != test.want {
+			t.Errorf("%s bet on a %s: got %d, want %d", test.bet, test.result, got, test.want)
+		}
+	}
+
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"log"
 	"strconv"
+
+	"github.com/bwmarrin/discordgo"
 )
 
 // BlackjackHand Represents a player's hand in a game of blackjack.
@@ -16,8 +20,8 @@ func (h BlackjackHand) Value() int {
 
 	// Getting the value and the number of aces in the deck
 	for i := range h {
-		value += ranks[h[i].Rank]
-		if h[i].Rank == "Ace" {
+		value += h[i].Rank.Value()
+		if h[i].Rank == Ace {
 			numAces++
 		}
 	}
@@ -32,69 +36,109 @@ func (h BlackjackHand) Value() int {
 
 }
 
-// SoftSeventeen Returns whether the value of the hand is a soft 17. A soft is a value with an ace counting as an 11.
-func (h BlackjackHand) SoftSeventeen() bool {
+// IsSoft Returns whether the value of the hand is soft. A soft value is one with an ace counting as an 11.
+func (h BlackjackHand) IsSoft() bool {
 
 	value := 0
 	numAces := 0
 
 	// Getting the value and the number of aces in the deck
 	for i := range h {
-		value += ranks[h[i].Rank]
-		if h[i].Rank == "Ace" {
+		value += h[i].Rank.Value()
+		if h[i].Rank == Ace {
 			numAces++
 		}
 	}
 
-	soft := false
-
 	// If there are more aces and using one as an 11 would not cause the hand to bust, it is a soft value
-	if numAces > 0 && value+10 <= 21 {
-		value += 10
-		numAces--
-		soft = true
-	}
+	return numAces > 0 && value+10 <= 21
+
+}
 
-	return value == 17 && soft
+// SoftSeventeen Returns whether the value of the hand is a soft 17. A soft is a value with an ace counting as an 11.
+func (h BlackjackHand) SoftSeventeen() bool {
+	return h.Value() == 17 && h.IsSoft()
+}
 
+// IsPair Returns whether the hand is two cards of the same rank, which can be split.
+func (h BlackjackHand) IsPair() bool {
+	return len(h) == 2 && h[0].Rank == h[1].Rank
 }
 
 // Implementing the stringer interface for BlackjackHand
 func (h BlackjackHand) String() string {
+	return h.StringIn(DefaultLocale)
+}
 
-	message := ""
+// StringIn Returns the cards in the hand and its value, in the given language
+func (h BlackjackHand) StringIn(locale string) string {
+	return RenderCards(h) + T(locale, "blackjack.hand_value", h.Value())
+}
 
-	// Making a message with the hand, adding each card to the message
-	for i, card := range h {
-		message += fmt.Sprintf("%s", card)
-		if i < len(h)-1 {
-			message += ","
-		}
-		message += "\n"
+// BlackjackRules The table rules a game of blackjack is played under. Used by the dealer logic and the strategy hints.
+type BlackjackRules struct {
+	// DealerChasesPlayer is the 1v1 variant where the dealer can see the player's hand and hits until they beat it.
+	// When false the dealer plays casino rules and hits on anything less than 17.
+	DealerChasesPlayer bool
+	// DealerHitsSoft17 is whether the dealer hits on a soft 17.
+	DealerHitsSoft17 bool
+	// DoubleAllowed is whether the player can double down on their first two cards.
+	DoubleAllowed bool
+	// SplitAllowed is whether the player can split a pair.
+	SplitAllowed bool
+}
+
+// DefaultBlackjackRules The rules games of blackjack are played with. Doubling and splitting aren't supported yet.
+var DefaultBlackjackRules = BlackjackRules{DealerChasesPlayer: true, DealerHitsSoft17: true}
+
+// DealerShouldHit Returns whether the dealer should take another card, given the dealer's hand and the player's hand value.
+func (r BlackjackRules) DealerShouldHit(dealerValue int, dealerSoft bool, playerValue int) bool {
+
+	// The dealer hits on anything less than 17, and also hits on a soft 17 (has an ace counting as 11) if the rules say so
+	hitsSeventeen := dealerValue < 17 || (r.DealerHitsSoft17 && dealerValue == 17 && dealerSoft)
+
+	if !r.DealerChasesPlayer {
+		return hitsSeventeen
 	}
 
-	message += "\nThe value is: " + strconv.Itoa(h.Value())
+	// This is the logic for 1v1 blackjack. The Dealer can see the player's hand and will continue to hit until they either beat them or bust
+	// If they are tied, the dealer will hit on a soft 17 or lower
+	return (dealerValue < playerValue && dealerValue < 21) || (dealerValue == playerValue && hitsSeventeen)
 
-	return message
 }
 
 // Blackjack Object representing a game of blackjack
 type Blackjack struct {
 	Player        Player
 	Wager         int
+	Rules         BlackjackRules
 	CardDeck      Deck
 	PlayerHand    BlackjackHand
 	DealerHand    BlackjackHand
 	IsPlayersTurn bool
 	ChannelID     string
+	// EscrowID is the escrow holding the wager until the game is over. Trainer games have no wager, so nothing is held.
+	EscrowID int64
+	// ID is the ID of the command that started the game, shown on the game's message
+	ID string
+	// MessageID is the message showing the game, which is edited in place as the game goes on
+	MessageID string
+	// Net is the chips the player won or lost, once the results are in
+	Net int
+	// Locale is the language the game's messages are shown in
+	Locale string
+	// Trainer games have no chips at stake, and each decision is checked against basic strategy
+	Trainer          bool
+	TrainerDecisions int
+	TrainerCorrect   int
 }
 
-// NewBlackjack Initializes and returns a new game of blackjack. Creates and shuffles a new deck, then deals player and dealer hands.
+// NewBlackjack Initializes and returns a new game of blackjack. Creates and shuffles a new shoe, then deals player and dealer hands.
 func NewBlackjack(player Player, wager int) Blackjack {
 
 	// Creating the new game
-	newGame := Blackjack{Player: player, Wager: wager, CardDeck: NewStandardDeck(), PlayerHand: make(BlackjackHand, 0),
-		DealerHand: make(BlackjackHand, 0), IsPlayersTurn: true}
+	newGame := Blackjack{Player: player, Wager: wager, Rules: DefaultBlackjackRules, CardDeck: NewShoe(Config.BlackjackDecks), PlayerHand: make(BlackjackHand, 0),
+		DealerHand: make(BlackjackHand, 0), IsPlayersTurn: true, Locale: DefaultLocale}
 
 	// shuffling deck
 	newGame.CardDeck.Shuffle()
@@ -113,29 +157,139 @@ func NewBlackjack(player Player, wager int) Blackjack {
 
 }
 
-// GetPlayerHand Returns a message with the player's hand
+// Colors of the bar down the side of the game's embed, for a game being played and each way it can end
+const (
+	BlackjackColorPlaying = 0x3498db
+	BlackjackColorWin     = 0x2ecc71
+	BlackjackColorLoss    = 0xe74c3c
+	BlackjackColorDraw    = 0x95a5a6
+)
+
+// GetPlayerHand Returns the player's hand for the game's embed. When the table is shown as an image, just the value is given.
 func (b *Blackjack) GetPlayerHand() string {
 
-	message := fmt.Sprintf("%s, your hand is:\n\n%s", (*b).Player.Username, (*b).PlayerHand)
+	if !Config.TextHands {
+		return T((*b).Locale, "blackjack.value", (*b).PlayerHand.Value())
+	}
 
-	return message
+	return (*b).PlayerHand.StringIn((*b).Locale)
 }
 
-// GetFullDealerHand Returns a message with the dealer's full hand
+// GetFullDealerHand Returns the dealer's full hand for the game's embed. When the table is shown as an image, just the value is given.
 func (b *Blackjack) GetFullDealerHand() string {
 
-	message := fmt.Sprintf("The dealer's hand is:\n\n%s", (*b).DealerHand)
+	if !Config.TextHands {
+		return T((*b).Locale, "blackjack.value", (*b).DealerHand.Value())
+	}
 
-	return message
+	return (*b).DealerHand.StringIn((*b).Locale)
 }
 
-// GetDealerHand Returns a message with the dealer's first card revealed, and the second card hidden. Only used at the start of the game, so player can see dealer's hand
-// One card is hidden for the dealer in blackjack rules
+// GetDealerHand Returns the dealer's hand for the game's embed with the first card revealed, and the second card hidden.
+// One card is hidden for the dealer in blackjack rules, until the player's turn is over.
 func (b *Blackjack) GetDealerHand() string {
 
-	message := fmt.Sprintf("The dealer's hand is:\n\n%s\n[Hidden Card]", (*b).DealerHand[0])
+	if !Config.TextHands {
+		return T((*b).Locale, "blackjack.showing", (*b).DealerHand[0])
+	}
 
-	return message
+	return fmt.Sprintf("%s %s", (*b).DealerHand[0], CardBack())
+
+}
+
+// Embed Returns the game's embed, with the hands, wager and balance, and a bar in the given color.
+// Once the player's turn is over the dealer's whole hand is shown.
+func (b *Blackjack) Embed(description string, color int) *discordgo.MessageEmbed {
+
+	dealer := (*b).GetDealerHand()
+	if !(*b).IsPlayersTurn {
+		dealer = (*b).GetFullDealerHand()
+	}
+
+	locale := (*b).Locale
+
+	title := T(locale, "blackjack.title", (*b).Player.Username)
+	wager := strconv.Itoa((*b).Wager)
+	if (*b).Trainer {
+		title = T(locale, "blackjack.trainer_title", (*b).Player.Username)
+		wager = T(locale, "blackjack.no_stake")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description,
+		Color:       color,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: T(locale, "blackjack.dealer_hand"), Value: dealer, Inline: true},
+			{Name: T(locale, "blackjack.player_hand", (*b).Player.Username), Value: (*b).GetPlayerHand(), Inline: true},
+			{Name: T(locale, "blackjack.wager"), Value: wager},
+			{Name: T(locale, "blackjack.balance"), Value: strconv.Itoa((*b).Player.Chips)},
+		},
+		Footer: &discordgo.MessageEmbedFooter{Text: T(locale, "blackjack.game_id", (*b).ID)},
+	}
+
+	if !Config.TextHands {
+		embed.Image = &discordgo.MessageEmbedImage{URL: "attachment://table.png"}
+	}
+
+	return embed
+
+}
+
+// ShowTable Shows the game in its message, sending the message the first time and editing it in place after that.
+// The table image is replaced each time, so the message only ever has the latest one.
+func (b *Blackjack) ShowTable(description string, color int, components []discordgo.MessageComponent) {
+
+	embeds := []*discordgo.MessageEmbed{(*b).Embed(description, color)}
+
+	if (*b).MessageID == "" {
+		message, err := s.ChannelMessageSendComplex((*b).ChannelID, &discordgo.MessageSend{
+			Embeds:     embeds,
+			Components: components,
+			Files:      (*b).TableFiles(),
+		})
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		(*b).MessageID = message.ID
+		return
+	}
+
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:          (*b).MessageID,
+		Channel:     (*b).ChannelID,
+		Embeds:      embeds,
+		Components:  components,
+		Files:       (*b).TableFiles(),
+		Attachments: &[]*discordgo.MessageAttachment{},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// TableFiles Returns an image of the table to attach to a message, with the dealer's second card face down during the
+// player's turn. Returns no files if hands are shown as text, or the image couldn't be made.
+func (b *Blackjack) TableFiles() []*discordgo.File {
+
+	if Config.TextHands {
+		return nil
+	}
+
+	wager := (*b).Wager
+	if (*b).Trainer {
+		wager = 0
+	}
+
+	image, err := EncodePNG(RenderBlackjackTable((*b).DealerHand, (*b).PlayerHand, (*b).IsPlayersTurn, wager))
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	return []*discordgo.File{{Name: "table.png", ContentType: "image/png", Reader: bytes.NewReader(image)}}
 
 }
 
@@ -147,35 +301,24 @@ func (b *Blackjack) Hit(h *BlackjackHand) {
 // RunDealerTurn Handles the Dealer turns in the game
 func (b *Blackjack) RunDealerTurn() {
 
-	//// This is the logic for casino blackjack where the Dealer is playing against more than one player
-	//// The dealer hits on anything less than 17, and also hits on a soft 17 (has an ace counting as 11)
-	//for (*b).DealerHand.Value() < 17 || (*b).DealerHand.SoftSeventeen() {
-	//	(*b).Hit(&(*b).DealerHand)
-	//}
-
-	// This is the logic for 1v1 blackjack. The Dealer can see the player's hand and will continue to hit until they either beat them or bust
-	// If they are tied, the dealer will hit on a soft 17 or lower
-	for ((*b).DealerHand.Value() < (*b).PlayerHand.Value() && (*b).DealerHand.Value() < 21) ||
-		((*b).DealerHand.Value() == (*b).PlayerHand.Value() && ((*b).DealerHand.Value() < 17 || (*b).DealerHand.SoftSeventeen())) {
+	for (*b).Rules.DealerShouldHit((*b).DealerHand.Value(), (*b).DealerHand.IsSoft(), (*b).PlayerHand.Value()) {
 		(*b).Hit(&(*b).DealerHand)
 	}
 
 }
 
-// RunPlayerTurn Handles the next Player turn in the game
+// RunPlayerTurn Handles the next Player turn in the game. Returns a message if the player's turn is over.
 func (b *Blackjack) RunPlayerTurn() string {
 
 	var message string
 
-	// If the player's hand is under 21, show them their hand (they get prompted by buttons now in main.go)
-	if (*b).PlayerHand.Value() < 21 {
-		message = fmt.Sprintln((*b).GetPlayerHand())
-	} else if (*b).PlayerHand.Value() > 21 {
+	// If the player's hand is under 21 they keep playing (they get prompted by buttons now in main.go)
+	if (*b).PlayerHand.Value() > 21 {
 		// Player must have busted
-		message = fmt.Sprintln((*b).PlayerBust())
-	} else {
-		// The player's hand is 21, so they cannot hit anymore. Display their hand then move to dealer turn
-		message = fmt.Sprintln((*b).GetPlayerHand())
+		message = (*b).PlayerBust()
+	} else if (*b).PlayerHand.Value() == 21 {
+		// The player's hand is 21, so they cannot hit anymore, and it moves to the dealer turn
+		message = T((*b).Locale, "blackjack.twenty_one")
 		// To get to this point the player either busts or stands
 		(*b).IsPlayersTurn = false
 	}
@@ -184,47 +327,52 @@ func (b *Blackjack) RunPlayerTurn() string {
 
 }
 
-// PlayerBust Displays the player's hand and a message that they have busted
+// PlayerBust Returns a message that the player has busted, and ends their turn
 func (b *Blackjack) PlayerBust() string {
 
-	message := fmt.Sprintln((*b).GetPlayerHand())
-	message += "\nUh oh, you bust!"
-
 	(*b).IsPlayersTurn = false
 
-	return message
+	return T((*b).Locale, "blackjack.bust")
 
 }
 
-// Results Displays the final results of the game
-func (b *Blackjack) Results() string {
+// Outcome Returns 1 if the player wins, 0 for a draw and -1 if the dealer wins.
+// Dealer wins if their hand is > player hand, and not above 21
+func (b *Blackjack) Outcome() int {
+
+	switch {
+	case (*b).PlayerHand.Value() > 21 || ((*b).DealerHand.Value() > (*b).PlayerHand.Value() && (*b).DealerHand.Value() <= 21):
+		return -1
+	case (*b).DealerHand.Value() == (*b).PlayerHand.Value():
+		return 0
+	default:
+		return 1
+	}
 
-	message := "=======================\n\t\t\t\tRESULTS\n=======================\n\n"
+}
 
-	// Displays both hands
-	message += (*b).GetPlayerHand() + "\n\n"
-	message += (*b).GetFullDealerHand() + "\n\n"
+// Results Works out the final results of the game, setting the player's net chips and stats.
+// Returns a message with the winner, and the color for the game's embed.
+func (b *Blackjack) Results() (string, int) {
 
-	// Determining the winner. Dealer wins if their hand is > player hand, and not above 21
-	if (*b).PlayerHand.Value() > 21 || ((*b).DealerHand.Value() > (*b).PlayerHand.Value() && (*b).DealerHand.Value() <= 21) {
-		message += "The dealer wins."
-		// If the player loses, they lose their wager. so we set it to negative here so when it is updated in game over,
-		// the wager is subtracted
-		(*b).Wager *= -1
+	switch (*b).Outcome() {
+	case -1:
+		// If the player loses, they lose their wager
+		(*b).Net = -(*b).Wager
 		// Updating the player's losses stat
 		(*b).Player.Losses++
-	} else if (*b).DealerHand.Value() == (*b).PlayerHand.Value() {
-		// Draw, set wager to 0, so they get their chips back
-		message += "It's a draw!"
-		(*b).Wager = 0
+		return T((*b).Locale, "blackjack.dealer_wins"), BlackjackColorLoss
+	case 0:
+		// Draw, so they get their chips back
+		(*b).Net = 0
 		// Updating the player's ties stat
 		(*b).Player.Ties++
-	} else {
-		message += (*b).Player.Username + " wins!"
+		return T((*b).Locale, "blackjack.draw"), BlackjackColorDraw
+	default:
+		(*b).Net = (*b).Wager
 		// Updating the player's wins stat
 		(*b).Player.Wins++
+		return T((*b).Locale, "blackjack.player_wins", (*b).Player.Username), BlackjackColorWin
 	}
 
-	return message
-
 }
@@ -0,0 +1,431 @@
+// This file implements head-to-head blackjack duels. Two members are dealt hands from the same shoe and take turns
+// hitting or standing. Each hand is hidden from the other player until both have finished, and the hand closest to 21
+// without going over wins the other player's stake.
+package main
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// BlackjackDuelTimeout is how long a player has to take their turn before they stand
+const BlackjackDuelTimeout = 2 * time.Minute
+
+// BlackjackDuelSeat One of the two players in a blackjack duel
+type BlackjackDuelSeat struct {
+	Username string
+	UserID   string
+	Hand     BlackjackHand
+	Stood    bool
+}
+
+// Done Returns whether the player has finished their hand, by standing or reaching 21 or more
+func (p *BlackjackDuelSeat) Done() bool {
+	return p.Stood || p.Hand.Value() >= 21
+}
+
+// BlackjackDuel A hand of blackjack between two members. The seats take turns to hit or stand, and the duel is over
+// once both have finished.
+type BlackjackDuel struct {
+	mu sync.Mutex
+
+	Duel      *Duel
+	Escrows   []int64
+	ChannelID string
+	MessageID string
+	CardDeck  Deck
+	Seats     []*BlackjackDuelSeat
+	// Turn is the index of the seat to act next
+	Turn int
+	// actions counts the hits and stands, so the timeout can tell if anyone has acted since it was started
+	actions int
+}
+
+var (
+	// BlackjackDuelsMap blackjack duels being played, by channel ID
+	BlackjackDuelsMap = make(map[string]*BlackjackDuel)
+	blackjackDuelsMu  sync.Mutex
+)
+
+// NewBlackjackDuel Shuffles a shoe and deals both players two cards. The first seat acts first. Players dealt 21
+// have nothing to decide, so they're finished straight away and skipped.
+func NewBlackjackDuel(seats ...*BlackjackDuelSeat) *BlackjackDuel {
+
+	game := &BlackjackDuel{CardDeck: NewShoe(Config.BlackjackDecks), Seats: seats}
+
+	for _, seat := range seats {
+		seat.Hand = BlackjackHand{game.CardDeck.DealCard(), game.CardDeck.DealCard()}
+	}
+	if seats[0].Done() {
+		game.nextTurn()
+	}
+
+	return game
+
+}
+
+// Seat Returns the seat of the player, or nil if they aren't in the duel
+func (g *BlackjackDuel) Seat(userID string) *BlackjackDuelSeat {
+	for _, seat := range g.Seats {
+		if seat.UserID == userID {
+			return seat
+		}
+	}
+	return nil
+}
+
+// Over Returns whether both players have finished their hands
+func (g *BlackjackDuel) Over() bool {
+	for _, seat := range g.Seats {
+		if !seat.Done() {
+			return false
+		}
+	}
+	return true
+}
+
+// Current Returns the seat of the player whose turn it is
+func (g *BlackjackDuel) Current() *BlackjackDuelSeat {
+	return g.Seats[g.Turn]
+}
+
+// nextTurn Passes the turn to the other player, unless they've finished their hand, in which case the current
+// player goes again
+func (g *BlackjackDuel) nextTurn() {
+	if other := (g.Turn + 1) % len(g.Seats); !g.Seats[other].Done() {
+		g.Turn = other
+	}
+}
+
+// playing Returns the player's seat, or an error if they can't hit or stand
+func (g *BlackjackDuel) playing(userID string) (*BlackjackDuelSeat, error) {
+
+	seat := g.Seat(userID)
+	if seat == nil {
+		return nil, NewLocalizedError("bjduel.error.not_playing")
+	}
+	if seat.Done() {
+		return nil, NewLocalizedError("bjduel.error.finished")
+	}
+	if seat != g.Current() {
+		return nil, NewLocalizedError("bjduel.error.not_your_turn")
+	}
+
+	return seat, nil
+
+}
+
+// Hit Deals a card to the player and passes the turn. Their hand is finished if they reach 21 or bust.
+func (g *BlackjackDuel) Hit(userID string) error {
+
+	seat, err := g.playing(userID)
+	if err != nil {
+		return err
+	}
+
+	seat.Hand = append(seat.Hand, g.CardDeck.DealCard())
+	g.actions++
+	g.nextTurn()
+
+	return nil
+
+}
+
+// Stand Finishes the player's hand and passes the turn
+func (g *BlackjackDuel) Stand(userID string) error {
+
+	seat, err := g.playing(userID)
+	if err != nil {
+		return err
+	}
+
+	seat.Stood = true
+	g.actions++
+	g.nextTurn()
+
+	return nil
+
+}
+
+// Result Returns 1 if the first seat wins, -1 if the second seat wins, and 0 for a draw.
+// The hand closest to 21 wins, a bust loses to any hand that didn't bust, and two busts are a draw.
+func (g *BlackjackDuel) Result() int {
+
+	first, second := g.Seats[0].Hand.Value(), g.Seats[1].Hand.Value()
+
+	// A bust counts as the lowest possible hand
+	if first > 21 {
+		first = 0
+	}
+	if second > 21 {
+		second = 0
+	}
+
+	switch {
+	case first > second:
+		return 1
+	case second > first:
+		return -1
+	default:
+		return 0
+	}
+
+}
+
+// Content Returns the duel message. The hands are only shown once both players have finished, and until then it
+// shows whose turn it is.
+func (g *BlackjackDuel) Content() string {
+
+	var sb strings.Builder
+
+	locale := g.Duel.Locale
+	sb.WriteString(T(locale, "bjduel.title", g.Duel.Stake))
+
+	if !g.Over() {
+		for _, seat := range g.Seats {
+			if seat.Done() {
+				sb.WriteString(T(locale, "bjduel.finished", seat.Username))
+			} else {
+				sb.WriteString(TN(locale, "bjduel.cards", len(seat.Hand), seat.Username, len(seat.Hand)))
+			}
+		}
+		sb.WriteString(T(locale, "bjduel.turn", g.Current().UserID))
+		return sb.String()
+	}
+
+	for _, seat := range g.Seats {
+		sb.WriteString(T(locale, "bjduel.hand", seat.Username, seat.Hand.StringIn(locale)))
+		if seat.Hand.Value() > 21 {
+			sb.WriteString(T(locale, "bjduel.bust"))
+		}
+		sb.WriteString("\n\n")
+	}
+
+	return sb.String()
+
+}
+
+// HandContent Returns the message showing the player their own hand, in the given language
+func (p *BlackjackDuelSeat) HandContent(locale string) string {
+
+	message := T(locale, "bjduel.your_hand", p.Hand.StringIn(locale))
+	switch {
+	case p.Hand.Value() > 21:
+		message += T(locale, "bjduel.bust")
+	case p.Done():
+		message += T(locale, "bjduel.you_finished")
+	}
+
+	return message
+
+}
+
+// Components Returns the hit, stand and my hand buttons, or no buttons once the duel is over
+func (g *BlackjackDuel) Components() []discordgo.MessageComponent {
+
+	if g.Over() {
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: T(g.Duel.Locale, "blackjack.hit_button"), Style: discordgo.PrimaryButton, CustomID: "bjduel-hit"},
+				discordgo.Button{Label: T(g.Duel.Locale, "blackjack.stand_button"), Style: discordgo.SecondaryButton, CustomID: "bjduel-stand"},
+				discordgo.Button{Label: T(g.Duel.Locale, "bjduel.hand_button"), Style: discordgo.SecondaryButton, CustomID: "bjduel-hand"},
+			},
+		},
+	}
+
+}
+
+// StartBlackjackDuel deals a blackjack duel once the opponent has accepted, in a new thread if the challenge was made
+// in a text channel
+func StartBlackjackDuel(i *discordgo.InteractionCreate, duel *Duel, opponent Player, opponentEscrow int64) {
+
+	game := NewBlackjackDuel(
+		&BlackjackDuelSeat{Username: opponent.Username, UserID: duel.OpponentID},
+		&BlackjackDuelSeat{Username: duel.Challenger.Username, UserID: duel.ChallengerID},
+	)
+	game.Duel = duel
+	game.Escrows = []int64{duel.EscrowID, opponentEscrow}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    i.Message.Content + "\n\n" + T(duel.Locale, "bjduel.accepts", opponent.Username),
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+	game.ChannelID = StartGameThread(i, T(duel.Locale, "bjduel.thread", duel.Challenger.Username, opponent.Username))
+
+	// If both players were dealt 21, the duel is already over
+	if game.Over() {
+		_, _ = s.ChannelMessageSend(game.ChannelID, game.Content()+game.finish())
+		return
+	}
+
+	// Games outside of text channels are played in the same channel, which can only hold one duel at a time
+	blackjackDuelsMu.Lock()
+	if _, ok := BlackjackDuelsMap[game.ChannelID]; ok {
+		blackjackDuelsMu.Unlock()
+		for _, id := range game.Escrows {
+			dba.RefundEscrow(id)
+		}
+		_, _ = s.ChannelMessageSend(game.ChannelID, T(duel.Locale, "bjduel.channel_busy"))
+		return
+	}
+	BlackjackDuelsMap[game.ChannelID] = game
+	blackjackDuelsMu.Unlock()
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	message, err := s.ChannelMessageSendComplex(game.ChannelID, &discordgo.MessageSend{
+		Content:    game.Content(),
+		Components: game.Components(),
+	})
+	if err != nil {
+		// Nobody can play without the message, so calling the duel off
+		log.Println(err)
+		blackjackDuelsMu.Lock()
+		delete(BlackjackDuelsMap, game.ChannelID)
+		blackjackDuelsMu.Unlock()
+		for _, id := range game.Escrows {
+			dba.RefundEscrow(id)
+		}
+		return
+	}
+	game.MessageID = message.ID
+	game.startTimer()
+
+}
+
+// startTimer Stands the player whose turn it is if they don't act within the timeout, so an idle player can't hold on
+// to both stakes. Should be called with the duel locked after every action.
+func (g *BlackjackDuel) startTimer() {
+
+	actions := g.actions
+
+	time.AfterFunc(BlackjackDuelTimeout, func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		// Someone acted in time, or the duel is over
+		if g.actions != actions || g.Over() {
+			return
+		}
+
+		idle := g.Current()
+		_ = g.Stand(idle.UserID)
+
+		content := g.Content()
+		if g.Over() {
+			blackjackDuelsMu.Lock()
+			delete(BlackjackDuelsMap, g.ChannelID)
+			blackjackDuelsMu.Unlock()
+
+			content += T(g.Duel.Locale, "bjduel.idle", idle.Username) + "\n\n" + g.finish()
+		} else {
+			content += "\n\n" + T(g.Duel.Locale, "bjduel.idle", idle.Username)
+			g.startTimer()
+		}
+		components := g.Components()
+		_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			Content:    &content,
+			Components: components,
+			ID:         g.MessageID,
+			Channel:    g.ChannelID,
+		})
+		if err != nil {
+			log.Println(err)
+		}
+	})
+
+}
+
+// finish settles the duel and returns a message with the result
+func (g *BlackjackDuel) finish() string {
+
+	// The first seat is the opponent, so the result is flipped to be from the challenger's side
+	return SettleDuel(g.Duel, g.Escrows, -g.Result())
+
+}
+
+// BlackjackDuelButton handles the hit, stand and my hand buttons in a blackjack duel. The duel message is updated to
+// show whose turn it is, and a player who hits is shown their new hand privately.
+func BlackjackDuelButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	blackjackDuelsMu.Lock()
+	game, ok := BlackjackDuelsMap[i.ChannelID]
+	blackjackDuelsMu.Unlock()
+
+	if !ok {
+		RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+		return
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	var err error
+	switch i.MessageComponentData().CustomID {
+	case "bjduel-hand":
+		seat := game.Seat(i.Member.User.ID)
+		if seat == nil {
+			RespondEphemeral(i, T(LocaleFor(i), "bjduel.not_playing"))
+			return
+		}
+		RespondEphemeral(i, seat.HandContent(LocaleFor(i)))
+		return
+	case "bjduel-hit":
+		err = game.Hit(i.Member.User.ID)
+	default:
+		err = game.Stand(i.Member.User.ID)
+	}
+	if err != nil {
+		RespondEphemeral(i, T(LocaleFor(i), "bjduel.cant", ErrorIn(LocaleFor(i), err)))
+		return
+	}
+	game.startTimer()
+
+	content := game.Content()
+	if game.Over() {
+		blackjackDuelsMu.Lock()
+		delete(BlackjackDuelsMap, game.ChannelID)
+		blackjackDuelsMu.Unlock()
+
+		content += game.finish()
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: game.Components(),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+	// Only the player sees the card they were dealt, until the hands are shown at the end
+	if i.MessageComponentData().CustomID == "bjduel-hit" && !game.Over() {
+		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content: game.Seat(i.Member.User.ID).HandContent(LocaleFor(i)),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+}
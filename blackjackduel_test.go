@@ -0,0 +1,48 @@
+package main
+
+import "testing"
+
+func TestBlackjackDuelTurns(t *testing.T) {
+
+	game := &BlackjackDuel{
+		CardDeck: Deck(pokerCards(t, "2C 2D 2H")),
+		Seats: []*BlackjackDuelSeat{
+			{Username: "A", UserID: "a", Hand: BlackjackHand(pokerCards(t, "10S 5D"))},
+			{Username: "B", UserID: "b", Hand: BlackjackHand(pokerCards(t, "9C 7H"))},
+		},
+	}
+
+	if err := game.Hit("b"); err == nil {
+		t.Error("the second seat could hit before the first")
+	}
+	if err := game.Hit("a"); err != nil {
+		t.Fatal(err)
+	}
+	if game.Current().UserID != "b" {
+		t.Fatalf("got %s's turn after a hit, want b", game.Current().UserID)
+	}
+	if err := game.Stand("a"); err == nil {
+		t.Error("the first seat could act twice in a row")
+	}
+
+	// Once a seat has finished, the other keeps the turn
+	if err := game.Stand("b"); err != nil {
+		t.Fatal(err)
+	}
+	if game.Current().UserID != "a" {
+		t.Fatalf("got %s's turn after a stand, want a", game.Current().UserID)
+	}
+	if err := game.Hit("a"); err != nil {
+		t.Fatal(err)
+	}
+	if game.Current().UserID != "a" || game.Over() {
+		t.Fatalf("got %s's turn with the duel over %v, want a's turn", game.Current().UserID, game.Over())
+	}
+	if err := game.Stand("a"); err != nil {
+		t.Fatal(err)
+	}
+	if !game.Over() {
+		t.Error("the duel isn't over after both players stood")
+	}
+
+}
@@ -3,36 +3,234 @@
 package main
 
 import (
-	"math/rand"
-	"time"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 )
 
+// Rank The rank of a playing card, ordered from Ace up to King with aces low
+type Rank int
+
+const (
+	Ace Rank = iota + 1
+	Two
+	Three
+	Four
+	Five
+	Six
+	Seven
+	Eight
+	Nine
+	Ten
+	Jack
+	Queen
+	King
+)
+
+// Ranks every rank in order, from Ace to King
+var Ranks = []Rank{Ace, Two, Three, Four, Five, Six, Seven, Eight, Nine, Ten, Jack, Queen, King}
+
+var (
+	rankKeys   = []string{"", "ace", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "jack", "queen", "king"}
+	rankShorts = []string{"", "A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}
+)
+
+// Implementing the stringer interface for Rank
+func (r Rank) String() string {
+	return r.StringIn(DefaultLocale)
+}
+
+// StringIn Returns the name of the rank in the given language
+func (r Rank) StringIn(locale string) string {
+	return TN(locale, "card."+rankKeys[r], 1)
+}
+
+// Plural Returns the name of the rank for more than one card, e.g. "Sixes"
+func (r Rank) Plural() string {
+	return r.PluralIn(DefaultLocale)
+}
+
+// PluralIn Returns the name of the rank for more than one card in the given language
+func (r Rank) PluralIn(locale string) string {
+	return TN(locale, "card."+rankKeys[r], 2)
+}
+
+// Short Returns the rank in short notation, e.g. "A", "10" or "K"
+func (r Rank) Short() string {
+	return rankShorts[r]
+}
+
+// Value Returns the value of the rank when cards are added up, like in blackjack and baccarat.
+// Aces are worth 1, and tens and pictures are all worth 10.
+func (r Rank) Value() int {
+	return min(int(r), 10)
+}
+
+// High Returns the rank's order from 2 to 14 with aces high, for games that compare cards by rank
+func (r Rank) High() int {
+	if r == Ace {
+		return 14
+	}
+	return int(r)
+}
+
+// Suit The suit of a playing card
+type Suit int
+
+const (
+	Clubs Suit = iota
+	Diamonds
+	Hearts
+	Spades
+)
+
+// Suits every suit, in the order decks are built in
+var Suits = []Suit{Clubs, Diamonds, Hearts, Spades}
+
+var (
+	suitNames   = []string{"Clubs", "Diamonds", "Hearts", "Spades"}
+	suitSymbols = []string{"♣", "♦", "♥", "♠"}
+	// suitGlyphs is where each suit's cards start in the Unicode playing cards block
+	suitGlyphs = []rune{0x1F0D0, 0x1F0C0, 0x1F0B0, 0x1F0A0}
+)
+
+// Implementing the stringer interface for Suit
+func (s Suit) String() string {
+	return suitNames[s]
+}
+
+// Symbol Returns the suit's symbol, e.g. "♠"
+func (s Suit) Symbol() string {
+	return suitSymbols[s]
+}
+
 // Card Represents a single playing card
 type Card struct {
-	Rank string
-	Suit string
+	Rank Rank
+	Suit Suit
 }
 
-// Implementing the stringer interface
+// Card styles the bot can show cards in, picked by CardStyle in the config
+const (
+	// CardStyleEmoji shows the rank and a suit emoji, e.g. "Q♥️"
+	CardStyleEmoji = "emoji"
+	// CardStyleUnicode shows the Unicode playing card, e.g. "🂽"
+	CardStyleUnicode = "unicode"
+	// CardStyleName shows the card's full name, e.g. "Queen of Hearts"
+	CardStyleName = "name"
+)
+
+// Implementing the stringer interface, showing the card in the configured style
 func (c Card) String() string {
-	return c.Rank + " of " + c.Suit
+
+	switch Config.CardStyle {
+	case CardStyleUnicode:
+		return c.Glyph()
+	case CardStyleName:
+		return c.Name()
+	default:
+		// The variation selector asks for the colored emoji of the suit, rather than the plain text symbol
+		return c.Rank.Short() + c.Suit.Symbol() + "\uFE0F"
+	}
+
+}
+
+// Name Returns the card's full name, e.g. "Queen of Hearts"
+func (c Card) Name() string {
+	return c.Rank.String() + " of " + c.Suit.String()
 }
 
-// Ranks for a typical deck of playing cards, mapped to their values
-var ranks = map[string]int{"Ace": 1, "Two": 2, "Three": 3, "Four": 4, "Five": 5, "Six": 6, "Seven": 7, "Eight": 8, "Nine": 9, "Ten": 10, "Jack": 10, "Queen": 10, "King": 10}
+// Short Returns the card in short notation, e.g. "QH" or "10S", which ParseCard can read back
+func (c Card) Short() string {
+	return c.Rank.Short() + c.Suit.String()[:1]
+}
+
+// Glyph Returns the card's Unicode playing card character, e.g. "🂽"
+func (c Card) Glyph() string {
+
+	offset := rune(c.Rank)
+	// The block has a Knight between the Jack and Queen, which isn't in a standard deck
+	if c.Rank >= Queen {
+		offset++
+	}
+
+	return string(suitGlyphs[c.Suit] + offset)
+
+}
+
+// CardBack Returns how a face down card is shown in the configured style
+func CardBack() string {
+	if Config.CardStyle == CardStyleName {
+		return "[Hidden Card]"
+	}
+	return "🂠"
+}
+
+// RenderCards Returns the cards in the configured style, separated by spaces, or commas for full names
+func RenderCards(cards []Card) string {
+
+	separator := " "
+	if Config.CardStyle == CardStyleName {
+		separator = ", "
+	}
+
+	parts := make([]string, len(cards))
+	for i, card := range cards {
+		parts[i] = card.String()
+	}
+
+	return strings.Join(parts, separator)
+
+}
+
+// ParseCard Reads a card in short notation, a rank then a suit, e.g. "AS", "10h", "Td" or "Q♥".
+// Tens can be written as 10 or T, and suits as a letter or a symbol, in either case.
+func ParseCard(notation string) (Card, error) {
+
+	notation = strings.ToUpper(strings.TrimSpace(strings.TrimSuffix(notation, "\uFE0F")))
+
+	suitRune, size := utf8.DecodeLastRuneInString(notation)
+	rankPart := notation[:len(notation)-size]
+
+	var card Card
+
+	switch suitRune {
+	case 'C', '♣':
+		card.Suit = Clubs
+	case 'D', '♦':
+		card.Suit = Diamonds
+	case 'H', '♥':
+		card.Suit = Hearts
+	case 'S', '♠':
+		card.Suit = Spades
+	default:
+		return Card{}, fmt.Errorf("%q doesn't end in a suit", notation)
+	}
+
+	if rankPart == "T" {
+		rankPart = "10"
+	}
+	for _, rank := range Ranks {
+		if rank.Short() == rankPart {
+			card.Rank = rank
+			return card, nil
+		}
+	}
+
+	return Card{}, fmt.Errorf("%q doesn't start with a rank", notation)
+
+}
 
 // Deck Represents a deck of playing cards
 type Deck []Card
 
-// NewStandardDeck Creates and returns a standard deck of 52 playing cards
+// NewStandardDeck Creates and returns a standard deck of 52 playing cards, in order by suit and then rank
 func NewStandardDeck() Deck {
-	var deck Deck
-
-	suits := []string{"Hearts", "Clubs", "Diamonds", "Spades"}
+	deck := make(Deck, 0, len(Suits)*len(Ranks))
 
 	// Looping through suits and ranks and creating a card of each combination, then adding to deck
-	for _, suit := range suits {
-		for rank := range ranks {
+	for _, suit := range Suits {
+		for _, rank := range Ranks {
 			deck = append(deck, Card{rank, suit})
 		}
 	}
@@ -41,29 +239,31 @@ func NewStandardDeck() Deck {
 
 }
 
-// Implements the stringer function for a deck, listing out the contents of the deck on a new line.
-func (d *Deck) String() string {
+// NewShoe Creates and returns a shuffled shoe made up of the given number of standard decks
+func NewShoe(decks int) Deck {
 
-	message := ""
+	var shoe Deck
 
-	for i, c := range *d {
-		message += c.String()
-		if i < len(*d)-1 {
-			message += ",\n"
-		}
+	for i := 0; i < decks; i++ {
+		shoe = append(shoe, NewStandardDeck()...)
 	}
 
-	return message
+	shoe.Shuffle()
+
+	return shoe
 
 }
 
+// Implements the stringer function for a deck, listing out the contents of the deck.
+func (d *Deck) String() string {
+	return RenderCards(*d)
+}
+
 // Shuffle Shuffles a deck of cards
 func (d *Deck) Shuffle() {
 
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-
 	for i := range *d {
-		j := rng.Intn(len(*d))
+		j := RNG.Intn(len(*d))
 		(*d)[i], (*d)[j] = (*d)[j], (*d)[i]
 	}
 
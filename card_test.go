@@ -0,0 +1,84 @@
+package main
+
+import "testing"
+
+func TestParseCardShort(t *testing.T) {
+
+	for _, suit := range Suits {
+		for _, rank := range Ranks {
+			card := Card{Rank: rank, Suit: suit}
+			got, err := ParseCard(card.Short())
+			if err != nil {
+				t.Errorf("%s: %v", card.Short(), err)
+				continue
+			}
+			if got != card {
+				t.Errorf("%s: got %s, want %s", card.Short(), got.Name(), card.Name())
+			}
+		}
+	}
+
+}
+
+func TestParseCard(t *testing.T) {
+
+	tests := []struct {
+		notation string
+		want     Card
+	}{
+		{"AS", Card{Ace, Spades}},
+		{"as", Card{Ace, Spades}},
+		{"10h", Card{Ten, Hearts}},
+		{"TH", Card{Ten, Hearts}},
+		{"td", Card{Ten, Diamonds}},
+		{"Q♥", Card{Queen, Hearts}},
+		{"Q♥️", Card{Queen, Hearts}},
+		{"2♣", Card{Two, Clubs}},
+		{"k♠", Card{King, Spades}},
+		{" 7d ", Card{Seven, Diamonds}},
+	}
+
+	for _, test := range tests {
+		got, err := ParseCard(test.notation)
+		if err != nil {
+			t.Errorf("%q: %v", test.notation, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q: got %s, want %s", test.notation, got.Name(), test.want.Name())
+		}
+	}
+
+}
+
+func TestParseCardRejects(t *testing.T) {
+
+	for _, notation := range []string{"", "H", "10", "1H", "11S", "AX", "QQH", "1OH", "Joker"} {
+		if card, err := ParseCard(notation); err == nil {
+			t.Errorf("%q: got %s, want an error", notation, card.Name())
+		}
+	}
+
+}
+
+func TestCardGlyph(t *testing.T) {
+
+	tests := []struct {
+		card Card
+		want string
+	}{
+		{Card{Ace, Spades}, "🂡"},
+		{Card{Ten, Diamonds}, "🃊"},
+		{Card{Jack, Clubs}, "🃛"},
+		// The Knight sits between the Jack and Queen in the Unicode block, so the Queen and King skip past it
+		{Card{Queen, Hearts}, "🂽"},
+		{Card{King, Spades}, "🂮"},
+	}
+
+	for _, test := range tests {
+		if got := test.card.Glyph(); got != test.want {
+			t.Errorf("%s: got %U, want %U", test.card.Name(), []rune(got), []rune(test.want))
+		}
+	}
+
+}
@@ -0,0 +1,578 @@
+// This file is the English message catalog. Every message the bot translates has to be here, since English is used
+// for any message another language is missing.
+package main
+
+// EnglishCatalog the English messages, by key
+var EnglishCatalog = Catalog{
+	"language.name":          {Other: "English"},
+	"language.user_set":      {Other: "I'll talk to you in %s from now on."},
+	"language.user_reset":    {Other: "I'll talk to you in your server's language, or your Discord language, from now on."},
+	"language.guild_set":     {Other: "This server's language is now %s. Members can still pick their own with /language me."},
+	"language.guild_reset":   {Other: "This server no longer has a language set, so I'll use each member's Discord language."},
+	"language.no_permission": {Other: "You need the Manage Server permission to change the server's language."},
+
+	"balance": {Other: "%s, your chip total is: %d"},
+
+	"leaderboard.title.wins":  {Other: "WINS LEADERBOARD"},
+	"leaderboard.title.chips": {Other: "CHIPS LEADERBOARD"},
+	"leaderboard.rank":        {Other: "RANK"},
+	"leaderboard.player":      {Other: "PLAYER"},
+	"leaderboard.wins":        {Other: "WINS"},
+	"leaderboard.ties":        {Other: "TIES"},
+	"leaderboard.losses":      {Other: "LOSSES"},
+	"leaderboard.chips":       {Other: "CHIPS"},
+
+	"game.not_enough_chips": {Other: "You don't have enough chips for that wager! Your current balance is: %d"},
+	"game.in_game":          {Other: "You're currently in a game elsewhere! Finish that game first."},
+	"game.in_game_force":    {Other: "You're currently in a game elsewhere! Finish that game first, or use the force flag to start a new game."},
+	"game.forfeit": {
+		One:   "Stopping your other game to start a new one! Your previous wager of %d chip was forfeited.",
+		Other: "Stopping your other game to start a new one! Your previous wager of %d chips was forfeited.",
+	},
+	"game.channel_ours": {Other: "We're already playing a game here!"},
+	"game.channel_busy": {Other: "I'm currently playing a game with %s in this channel. Please try another channel."},
+
+	"blackjack.starting":         {Other: "Starting a new game of blackjack with %s!"},
+	"blackjack.trainer_starting": {Other: "Starting a blackjack trainer game with %s! No chips are at stake, I'll let you know if you make a mistake."},
+	"blackjack.title":            {Other: "Blackjack with %s"},
+	"blackjack.trainer_title":    {Other: "Blackjack Trainer with %s"},
+	"blackjack.hit":              {Other: "You chose to hit! "},
+	"blackjack.stand":            {Other: "You stand!"},
+	"blackjack.hit_or_stand":     {Other: "Hit or stand?"},
+	"blackjack.twenty_one":       {Other: "21! It's the dealer's turn."},
+	"blackjack.bust":             {Other: "Uh oh, you bust!"},
+	"blackjack.hit_button":       {Other: "Hit"},
+	"blackjack.stand_button":     {Other: "Stand"},
+	"blackjack.hint_button":      {Other: "Hint"},
+	"blackjack.dealer_wins":      {Other: "The dealer wins."},
+	"blackjack.draw":             {Other: "It's a draw!"},
+	"blackjack.player_wins":      {Other: "%s wins!"},
+	"blackjack.dealer_hand":      {Other: "Dealer's hand"},
+	"blackjack.player_hand":      {Other: "%s's hand"},
+	"blackjack.showing":          {Other: "Showing %s"},
+	"blackjack.value":            {Other: "Value: %d"},
+	"blackjack.wager":            {Other: "Wager"},
+	"blackjack.no_stake":         {Other: "No chips at stake"},
+	"blackjack.balance":          {Other: "Balance"},
+	"blackjack.game_id":          {Other: "Game ID: %s"},
+	"blackjack.trainer_result":   {Other: " No chips were at stake."},
+	"blackjack.trainer_decisions": {
+		One:   "You made %d out of %d decision correctly this hand.",
+		Other: "You made %d out of %d decisions correctly this hand.",
+	},
+
+	"net.returned": {Other: " Your wager was returned."},
+	"net.gained": {
+		One:   " You've gained %d chip!",
+		Other: " You've gained %d chips!",
+	},
+	"net.lost": {
+		One:   " You lost %d chip.",
+		Other: " You lost %d chips.",
+	},
+	"net.pity":  {Other: "\n\nUh oh, looks like you lost the last of your chips! I'll put your total back up to %d, so you can keep playing."},
+	"net.total": {Other: "\n\nYour new chip total is: %d"},
+
+	"reward.claimed.daily":   {Other: "You claimed your daily %d chips!"},
+	"reward.claimed.hourly":  {Other: "You claimed your hourly %d chips!"},
+	"reward.streak":          {Other: " That's a %d day streak, which added %d bonus chips. Come back tomorrow to keep it going!"},
+	"reward.cooldown.daily":  {Other: "You've already claimed your daily chips. You can claim them again <t:%d:R>."},
+	"reward.cooldown.hourly": {Other: "You've already claimed your hourly chips. You can claim them again <t:%d:R>."},
+
+	"give.self": {Other: "You have to give chips to another member!"},
+	"give.account_too_new": {
+		One:   "Your Discord account has to be at least %d day old to give chips away.",
+		Other: "Your Discord account has to be at least %d days old to give chips away.",
+	},
+	"give.limit":          {Other: "You can give away up to %d chips a day, and you can only give %d more right now."},
+	"give.limit_reached":  {Other: "That transfer would take you over the daily limit of %d chips, so it wasn't made."},
+	"give.confirm":        {Other: "Give %d chips to %s? The house takes %d in tax, so they'll get %d."},
+	"give.confirm_button": {Other: "Give"},
+	"give.cancel_button":  {Other: "Cancel"},
+	"give.expired":        {Other: "The transfer wasn't confirmed in time, so no chips were given."},
+	"give.cancelled":      {Other: "The transfer was cancelled, so no chips were given."},
+	"give.sent":           {Other: "You gave %d chips to %s!"},
+	"give.announce":       {Other: "<@%s>, %s gave you %d chips!"},
+
+	"loan.taken":            {Other: "You borrowed %d chips! Interest of %.1f%% is added every day, and the loan is due <t:%d:R>."},
+	"loan.over_limit":       {Other: "You can borrow up to %d chips."},
+	"loan.outstanding":      {Other: "You already have a loan! Pay it off with /loan repay before borrowing again."},
+	"loan.defaulted":        {Other: "Your loan is in default, so you can't borrow again until you've paid it off."},
+	"loan.none":             {Other: "You don't have a loan."},
+	"loan.credit":           {Other: " You can borrow up to %d chips."},
+	"loan.status":           {Other: "You owe %d chips, due <t:%d:R>. Interest of %.1f%% is added every day until it's paid off."},
+	"loan.status_defaulted": {Other: "You owe %d chips, which was due <t:%d:R>. Your loan is in default, so you can't borrow again until you've paid it off."},
+	"loan.repaid":           {Other: "You paid %d chips towards your loan. You still owe %d."},
+	"loan.repaid_all":       {Other: "You paid %d chips and your loan is paid off!"},
+	"loan.auto_repaid":      {Other: "\n\n%d chips of your winnings went towards your loan. You still owe %d."},
+	"loan.auto_repaid_all":  {Other: "\n\n%d chips of your winnings paid off your loan!"},
+
+	"profile.title":          {Other: "%s's profile"},
+	"profile.chips":          {Other: "%d chips"},
+	"profile.record":         {Other: "Record"},
+	"profile.record_value":   {Other: "%d wins, %d ties, %d losses"},
+	"profile.loan_title":     {Other: "Loan"},
+	"profile.no_loan":        {Other: "None"},
+	"profile.loan":           {Other: "%d chips, due <t:%d:R>"},
+	"profile.loan_defaulted": {Other: "%d chips, **in default** since <t:%d:R>"},
+	"profile.credit":         {Other: "Credit limit"},
+	"profile.defaults":       {Other: "Loan history"},
+	"profile.defaults_value": {Other: "%d repaid, %d defaulted"},
+
+	"baccarat.player":     {Other: "Player"},
+	"baccarat.banker":     {Other: "Banker"},
+	"baccarat.tie":        {Other: "Tie"},
+	"baccarat.bets":       {Other: "%s bets %d on %s.\n\n"},
+	"baccarat.hand":       {Other: "%s: %s (%d)\n"},
+	"baccarat.tie_result": {Other: "It's a tie!"},
+	"baccarat.wins":       {Other: "%s wins!"},
+	"baccarat.bead_road":  {Other: "\n\nBead road:\n"},
+
+	"war.playing":          {Other: "%s is playing war for %d chips.\n\n"},
+	"war.burned":           {Other: "\n**War!** %d cards are burned.\n"},
+	"war.player_card":      {Other: "%s's card: %s\n"},
+	"war.dealer_card":      {Other: "Dealer's card: %s\n"},
+	"war.go_button":        {Other: "Go to war (raise %d)"},
+	"war.surrender_button": {Other: "Surrender (lose %d)"},
+	"war.in_game":          {Other: "You're already at war! Go to war or surrender on that game first."},
+	"war.tie":              {Other: "It's a tie! Go to war, or surrender half your wager?"},
+	"war.win":              {Other: "Your card is higher, you win!"},
+	"war.lose":             {Other: "The dealer's card is higher, you lose."},
+	"war.not_yours":        {Other: "This isn't your game of war."},
+	"war.raise_short":      {Other: "You need another %d chips to go to war, and your current balance is: %d"},
+	"war.war_win":          {Other: "Your card is at least as high as the dealer's, you win the raise!"},
+	"war.war_lose":         {Other: "The dealer's card is higher, you lose your wager and the raise."},
+	"war.surrendered":      {Other: "You surrender half your wager."},
+
+	"keno.error.number": {Other: "%q isn't a number from 1 to %d"},
+	"keno.error.repeat": {Other: "%d is picked more than once"},
+	"keno.error.spots":  {Other: "pick from 1 to %d numbers"},
+	"keno.bad_numbers":  {Other: "Those numbers don't work: %s."},
+	"keno.bets":         {Other: "%s bets %d on keno.\n\n"},
+	"keno.numbers":      {Other: "Your numbers: %s\n"},
+	"keno.drawn":        {Other: "Drawn: %s\n\n"},
+	"keno.caught":       {Other: "You caught %d of %d"},
+	"keno.pays":         {Other: ", which pays %d!"},
+
+	"jackpot.announce":      {Other: "JACKPOT! %s hit %s and won %d chips from the jackpot!"},
+	"jackpot.won":           {Other: "\n\nJACKPOT! You hit %s and won %d chips from the jackpot! Your chip total is now: %d"},
+	"jackpot.pool":          {Other: "The jackpot is at %d chips! Hit three sevens in blackjack, or a line of the top symbol on the slots, to win it. Three suited sevens in blackjack win the whole pool!"},
+	"jackpot.reason.sevens": {Other: "three sevens"},
+	"jackpot.reason.slots":  {Other: "a line of %s"},
+
+	"slots.closed":           {Other: "The slot machine is closed right now."},
+	"slots.not_enough_chips": {Other: "You don't have enough chips for that bet! %d on each of the %d paylines is %d, and your current balance is: %d"},
+	"slots.title":            {Other: "%s bets %d on %d paylines and pulls the lever...\n\n"},
+	"slots.line_wins":        {Other: "Payline %d wins %d!\n"},
+	"slots.no_wins":          {Other: "No winning lines."},
+
+	"roulette.wheel.european":    {Other: "European"},
+	"roulette.wheel.american":    {Other: "American"},
+	"roulette.colour.red":        {Other: "red"},
+	"roulette.colour.black":      {Other: "black"},
+	"roulette.colour.green":      {Other: "green"},
+	"roulette.ordinal.1":         {Other: "1st"},
+	"roulette.ordinal.2":         {Other: "2nd"},
+	"roulette.ordinal.3":         {Other: "3rd"},
+	"roulette.bet":               {Other: "%s for %d"},
+	"roulette.bet_on":            {Other: "%s %s for %d"},
+	"roulette.bet_wins":          {Other: "%s wins %d\n"},
+	"roulette.bet_loses":         {Other: "%s loses\n"},
+	"roulette.bad_bets":          {Other: "I couldn't understand your bets: %s."},
+	"roulette.cant_place":        {Other: "I couldn't place your bets: %s."},
+	"roulette.cant_settle":       {Other: "I couldn't settle your bets: %s."},
+	"roulette.not_enough_chips":  {Other: "You don't have enough chips for those bets! They add up to %d, and your current balance is: %d"},
+	"roulette.spins":             {Other: "%s spins the %s wheel...\n\n"},
+	"roulette.lands":             {Other: "The ball lands on **%s %s**!\n\n"},
+	"roulette.player_wins":       {Other: "%s wins!"},
+	"roulette.house_wins":        {Other: "The house wins."},
+	"roulette.even":              {Other: "You broke even!"},
+	"roulette.error.double_zero": {Other: "00 is only on the American wheel"},
+	"roulette.error.pocket":      {Other: "%q isn't a number on the wheel"},
+	"roulette.error.too_short":   {Other: "%q needs a bet type and an amount"},
+	"roulette.error.amount":      {Other: "%q doesn't end with a valid amount of chips"},
+	"roulette.error.too_many":    {Other: "%q is more chips than you have"},
+	"roulette.error.outside":     {Other: "%q should just be the bet type and the amount"},
+	"roulette.error.inside":      {Other: "%q should be the bet type, the numbers, then the amount"},
+	"roulette.error.which":       {Other: "%q should pick the 1st, 2nd or 3rd %s"},
+	"roulette.error.straight":    {Other: "%q should be on a single number"},
+	"roulette.error.split":       {Other: "%q should be on two numbers next to each other"},
+	"roulette.error.zero":        {Other: "%q can't include a zero"},
+	"roulette.error.street":      {Other: "%q should be a row of three numbers, like 4-5-6"},
+	"roulette.error.corner":      {Other: "%q should be four numbers in a square, like 1-2-4-5"},
+	"roulette.error.line":        {Other: "%q should be two rows next to each other, like 1-6"},
+	"roulette.error.type":        {Other: "%q isn't a type of bet"},
+	"roulette.error.no_bets":     {Other: "you didn't place any bets"},
+	"roulette.error.overflow":    {Other: "those bets add up to more chips than I can count"},
+
+	"roulette_table.closed":  {Other: "The %s roulette table is closed.\n\n"},
+	"roulette_table.open":    {Other: "The %s roulette table is open! Betting closes <t:%d:R>.\nPick a bet and an amount then press Place bet, or press Inside bet to type out a bet on numbers.\n\n"},
+	"roulette_table.no_bets": {Other: "No bets yet."},
+	"roulette_table.no_spin": {Other: "Betting is closed. Nobody placed a bet, so the wheel isn't spun."},
+	"roulette_table.spins":   {Other: "Betting is closed! The wheel spins...\n\n"},
+	"roulette_table.result":  {Other: "**%s**: %+d chips (now %d)\n"},
+	"roulette_table.chips": {
+		One:   "%d chip",
+		Other: "%d chips",
+	},
+	"roulette_table.pick_bet":               {Other: "Pick a bet"},
+	"roulette_table.pick_amount":            {Other: "Pick an amount"},
+	"roulette_table.place_button":           {Other: "Place bet"},
+	"roulette_table.inside_button":          {Other: "Inside bet"},
+	"roulette_table.clear_button":           {Other: "Clear my bets"},
+	"roulette_table.already_open":           {Other: "There's already a roulette table open in this channel! Place your bets there."},
+	"roulette_table.pick_first":             {Other: "Pick a bet and an amount from the menus first!"},
+	"roulette_table.cant_place":             {Other: "I couldn't place that bet: %s."},
+	"roulette_table.modal_title":            {Other: "Place an inside bet"},
+	"roulette_table.modal_label":            {Other: "Bet"},
+	"roulette_table.betting_closed":         {Other: "Betting has closed at this table."},
+	"roulette_table.bad_bet":                {Other: "I couldn't understand your bet: %s."},
+	"roulette_table.cleared":                {Other: "Your bets have been taken off the table."},
+	"roulette_table.placed":                 {Other: "Bet placed: %s."},
+	"roulette_table.error.closed":           {Other: "betting is closed"},
+	"roulette_table.error.not_enough_chips": {Other: "you don't have enough chips for that bet. Your current balance is: %d"},
+
+	"crash.error.no_bet":      {Other: "you don't have a bet in this round"},
+	"crash.error.not_started": {Other: "the multiplier hasn't started climbing yet"},
+	"crash.error.cashed_out":  {Other: "you already cashed out at %.2fx"},
+	"crash.error.too_late":    {Other: "too late, it crashed at %.2fx"},
+	"crash.crashed":           {Other: "💥 **CRASHED at %.2fx!**\n\n"},
+	"crash.starting":          {Other: "A round of crash starts in %d seconds! Use /crash to bet.\n\n"},
+	"crash.bet_cashed_out":    {Other: "%s cashed out %d at %.2fx\n"},
+	"crash.bet_lost":          {Other: "%s lost %d\n"},
+	"crash.bet_in":            {Other: "%s is in for %d\n"},
+	"crash.seeds":             {Other: "\nServer seed hash: `%s`\nClient seed: `%s`"},
+	"crash.server_seed":       {Other: "\nServer seed: `%s`"},
+	"crash.cash_out_button":   {Other: "Cash out"},
+	"crash.pity":              {Other: "%s lost the last of their chips, so I've put them back up to %d."},
+	"crash.already_started":   {Other: "This round has already started! Wait for it to crash, then bet on the next one."},
+	"crash.already_bet":       {Other: "You've already bet on this round."},
+	"crash.bets":              {Other: "%s bets %d on crash."},
+	"crash.over":              {Other: "This round is over."},
+	"crash.cant_cash_out":     {Other: "You can't cash out: %s."},
+	"crash.cashed_out":        {Other: "You cashed out at %.2fx!"},
+
+	"hilo.playing":         {Other: "%s is playing hi-lo for %d chips.\n\n"},
+	"hilo.previous":        {Other: "Previous cards: %s\n"},
+	"hilo.current":         {Other: "Current card: **%s**\n\n"},
+	"hilo.multiplier":      {Other: "Multiplier: **%.2fx**"},
+	"hilo.higher_button":   {Other: "Higher (%.2fx)"},
+	"hilo.lower_button":    {Other: "Lower (%.2fx)"},
+	"hilo.cash_out_button": {Other: "Cash out (%d)"},
+	"hilo.wrong":           {Other: "Wrong guess! %s loses."},
+	"hilo.cashed_out":      {Other: "%s cashed out at %.2fx for %d chips!"},
+	"hilo.in_game":         {Other: "You're already playing hi-lo! Finish that game first."},
+	"hilo.first_guess":     {Other: "Will the next card be higher or lower?"},
+	"hilo.not_yours":       {Other: "This isn't your game of hi-lo."},
+	"hilo.guess_first":     {Other: "Make at least one guess before cashing out."},
+	"hilo.cant_win":        {Other: "That guess can't win!"},
+	"hilo.right":           {Other: "Right! Higher or lower?"},
+	"hilo.push":            {Other: "Same rank, that's a push! Higher or lower?"},
+	"hilo.last_card":       {Other: "That was the last card! "},
+
+	"mines.playing":         {Other: "%s is playing mines with %d mines for %d chips.\n"},
+	"mines.multiplier":      {Other: "Multiplier: **%.2fx**"},
+	"mines.next_tile":       {Other: ", next tile: %.2fx"},
+	"mines.hash":            {Other: "\nBoard hash: `%s`"},
+	"mines.layout":          {Other: "\nSalt: `%s`\nMines: `%s`"},
+	"mines.boom":            {Other: "💥 Boom! %s hit a mine!"},
+	"mines.cashed_out":      {Other: "%s cashed out at %.2fx for %d chips!"},
+	"mines.in_game":         {Other: "You're already playing mines! Finish that game first."},
+	"mines.cash_out_prompt": {Other: "Cash out whenever you like!"},
+	"mines.cash_out_button": {Other: "Cash out"},
+	"mines.not_yours":       {Other: "This isn't your game of mines."},
+	"mines.over":            {Other: "This game is over."},
+	"mines.reveal_first":    {Other: "Reveal at least one tile before cashing out."},
+
+	"poker.high_card":       {Other: "High Card"},
+	"poker.one_pair":        {Other: "One Pair"},
+	"poker.two_pair":        {Other: "Two Pair"},
+	"poker.three_of_a_kind": {Other: "Three of a Kind"},
+	"poker.straight":        {Other: "Straight"},
+	"poker.flush":           {Other: "Flush"},
+	"poker.full_house":      {Other: "Full House"},
+	"poker.four_of_a_kind":  {Other: "Four of a Kind"},
+	"poker.straight_flush":  {Other: "Straight Flush"},
+	"poker.royal_flush":     {Other: "Royal Flush"},
+
+	"videopoker.playing":     {Other: "%s is playing Jacks or Better for %d chips.\n\n"},
+	"videopoker.held":        {Other: " (held)"},
+	"videopoker.hold_button": {Other: "Hold %d"},
+	"videopoker.draw_button": {Other: "Draw"},
+	"videopoker.in_game":     {Other: "You're already playing a hand of video poker! Draw on that hand first."},
+	"videopoker.hold_prompt": {Other: "Pick the cards to hold, then draw."},
+	"videopoker.not_yours":   {Other: "This isn't your hand of video poker."},
+	"videopoker.no_win":      {Other: "No win."},
+	"videopoker.pays":        {Other: "%s pays %d!"},
+
+	"craps.bet.pass":     {Other: "Pass line"},
+	"craps.bet.dontpass": {Other: "Don't pass"},
+	"craps.bet.come":     {Other: "Come"},
+	"craps.bet.dontcome": {Other: "Don't come"},
+	"craps.bet.place":    {Other: "Place"},
+	"craps.bet.field":    {Other: "Field"},
+
+	"craps.error.line_bet":    {Other: "line bets can only be made before the come out roll"},
+	"craps.error.come_bet":    {Other: "come bets can only be made once a point is set. Bet on the pass line instead"},
+	"craps.error.place_bet":   {Other: "place bets can only be on 4, 5, 6, 8, 9 or 10"},
+	"craps.error.type":        {Other: "%q isn't a craps bet"},
+	"craps.error.line_odds":   {Other: "odds can only be added to a line bet once a point is set"},
+	"craps.error.max_odds":    {Other: "odds can be at most %d times the bet, which is %d more chips"},
+	"craps.error.no_line_bet": {Other: "you don't have a line bet to add odds to"},
+	"craps.error.no_come_bet": {Other: "you don't have a come bet on %d to add odds to"},
+
+	"craps.point_off":        {Other: "The point is OFF. The next roll is a come out roll.\n"},
+	"craps.point":            {Other: "The point is %d.\n"},
+	"craps.no_shooter":       {Other: "Nobody has the dice. Anyone with a bet can roll.\n"},
+	"craps.shooter":          {Other: "%s is the shooter.\n"},
+	"craps.no_bets":          {Other: "\nThere are no bets on the table."},
+	"craps.player":           {Other: "PLAYER"},
+	"craps.bet":              {Other: "BET"},
+	"craps.amount":           {Other: "AMOUNT"},
+	"craps.odds":             {Other: "ODDS"},
+	"craps.not_enough_chips": {Other: "You don't have enough chips for that bet! Your current balance is: %d"},
+	"craps.cant_bet":         {Other: "You can't make that bet: %s."},
+	"craps.bets":             {Other: "%s bets %d on %s.\n\n%s"},
+	"craps.need_bet":         {Other: "You need a bet on the table to shoot. Start with a pass line bet!"},
+	"craps.not_shooter":      {Other: "Only the shooter can roll the dice. %s is shooting right now."},
+	"craps.rolls":            {Other: "%s rolls %d and %d for **%d**!\n"},
+	"craps.seven_out":        {Other: "Seven out!"},
+	"craps.dice_pass":        {Other: " The dice pass to %s."},
+	"craps.made_point":       {Other: "The shooter made the point!\n"},
+	"craps.wins":             {Other: "%s wins %d on %s."},
+	"craps.loses":            {Other: "%s loses %d on %s."},
+	"craps.pity":             {Other: "%s lost the last of their chips, so I've put them back up to %d."},
+
+	"lottery.no_tickets": {Other: "The lottery has been drawn, but nobody bought a ticket this time."},
+	"lottery.drawn":      {Other: "The lottery has been drawn! %s wins the pot of %d chips!"},
+	"lottery.next_draw":  {Other: " The next draw is <t:%d:R>."},
+	"lottery.not_enough_chips": {
+		One:   "%d ticket costs %d chips, and your current balance is: %d",
+		Other: "%d tickets cost %d chips, and your current balance is: %d",
+	},
+	"lottery.drawing": {Other: "The lottery is being drawn right now! Try again in a moment."},
+	"lottery.bought": {
+		One:   "%s buys %d lottery ticket for %d chips! Your chip total is now: %d\n\n",
+		Other: "%s buys %d lottery tickets for %d chips! Your chip total is now: %d\n\n",
+	},
+	"lottery.status": {Other: "The next lottery draw is <t:%d:R>. Tickets are %d chips each.\n"},
+	"lottery.pot": {
+		One:   "The pot is %d chips from %d ticket.",
+		Other: "The pot is %d chips from %d tickets.",
+	},
+	"lottery.mine": {
+		One:   " You have %d ticket, a %.1f%% chance of winning.",
+		Other: " You have %d tickets, a %.1f%% chance of winning.",
+	},
+
+	"counting.reshuffled":     {Other: "The shoe has been reshuffled. The count starts again from 0."},
+	"counting.running_answer": {Other: "Running count: you said %d, it was %d. %s\n"},
+	"counting.true_answer":    {Other: "True count: you said %d, it was %.2f (%.1f decks left). %s\n"},
+	"counting.answer_time":    {Other: "You answered in %.1f seconds."},
+	"counting.correct":        {Other: "Correct!"},
+	"counting.wrong":          {Other: "Wrong."},
+	"counting.over_empty":     {Other: "Counting session over. You didn't answer any count checks, so nothing was recorded."},
+	"counting.over":           {Other: "Counting session over!\n\nRunning count correct: %d out of %d\nTrue count correct: %d out of %d\nAverage answer time: %.1f seconds"},
+	"counting.no_stats":       {Other: "You haven't finished a card counting session yet. Use /count-trainer to start!"},
+	"counting.stats": {
+		One:   "Card counting: %d session, running count %.1f%% correct, true count %.1f%% correct, average answer time %.1f seconds.",
+		Other: "Card counting: %d sessions, running count %.1f%% correct, true count %.1f%% correct, average answer time %.1f seconds.",
+	},
+	"counting.prompt":         {Other: "What's the count?"},
+	"counting.enter_button":   {Other: "Enter count"},
+	"counting.stop_button":    {Other: "Stop"},
+	"counting.in_session":     {Other: "You're already in a counting session! Stop that one first."},
+	"counting.starting":       {Other: "Starting a card counting session with %s! Dealing from a %d deck shoe, one card every %.1f seconds. I'll ask for the Hi-Lo count every %d cards."},
+	"counting.thread":         {Other: "Card counting with %s"},
+	"counting.running_label":  {Other: "Running count"},
+	"counting.true_label":     {Other: "True count"},
+	"counting.running_number": {Other: "The running count has to be a whole number. Try again!"},
+	"counting.true_number":    {Other: "The true count has to be a whole number. Try again!"},
+
+	"trainer.no_stats":         {Other: "%s, you haven't made any decisions in the trainer yet. Use /blackjack-trainer to start!"},
+	"trainer.accuracy":         {Other: "%s TRAINER ACCURACY\n"},
+	"trainer.hands":            {Other: "HANDS"},
+	"trainer.correct":          {Other: "CORRECT"},
+	"trainer.total":            {Other: "TOTAL"},
+	"trainer.accuracy_column":  {Other: "ACCURACY"},
+	"trainer.category.hard":    {Other: "hard"},
+	"trainer.category.soft":    {Other: "soft"},
+	"trainer.category.pairs":   {Other: "pairs"},
+	"trainer.category.overall": {Other: "overall"},
+
+	"duel.heads":            {Other: "The coin lands on heads! %s called heads."},
+	"duel.tails":            {Other: "The coin lands on tails! %s called tails."},
+	"duel.rolls":            {Other: "%s rolls %d, %s rolls %d.\n"},
+	"duel.tie":              {Other: "It's a tie, roll again!\n"},
+	"duel.another_member":   {Other: "You have to challenge another member!"},
+	"duel.not_enough_chips": {Other: "You don't have enough chips for that stake! Your current balance is: %d"},
+	"duel.game.coin":        {Other: "a coin flip, calling heads,"},
+	"duel.game.dice":        {Other: "a dice roll"},
+	"duel.game.blackjack":   {Other: "a hand of blackjack"},
+	"duel.challenge":        {Other: "<@%s>, %s challenges you to %s for %d chips! You have %d minutes to accept."},
+	"duel.accept_button":    {Other: "Accept"},
+	"duel.decline_button":   {Other: "Decline"},
+	"duel.expired":          {Other: "%s's challenge to %s wasn't accepted in time, so the stake has been returned."},
+	"duel.not_yours":        {Other: "This challenge isn't for you!"},
+	"duel.cant_accept":      {Other: "You don't have enough chips to accept! Your current balance is: %d"},
+	"duel.draw":             {Other: "\n\nIt's a draw! Both stakes have been returned."},
+	"duel.wins":             {Other: "\n\n%s wins the pot of %d chips! Their chip total is now: %d"},
+	"duel.pity":             {Other: "%s lost the last of their chips, so I've put them back up to %d."},
+	"duel.declined":         {Other: "%s declined the challenge."},
+	"duel.called_off":       {Other: "%s called off the challenge."},
+	"duel.stake_returned":   {Other: " The stake has been returned."},
+
+	"bjduel.error.not_playing":   {Other: "you aren't playing in this duel"},
+	"bjduel.error.finished":      {Other: "you've already finished your hand"},
+	"bjduel.error.not_your_turn": {Other: "it isn't your turn"},
+	"bjduel.title":               {Other: "**Blackjack duel** for %d chips each\n\n"},
+	"bjduel.finished":            {Other: "%s has finished their hand.\n"},
+	"bjduel.cards": {
+		One:   "%s has %d card.\n",
+		Other: "%s has %d cards.\n",
+	},
+	"bjduel.turn":         {Other: "\nIt's <@%s>'s turn. The hands are hidden until both players have finished, so press My hand to see your cards, then hit or stand."},
+	"bjduel.hand":         {Other: "%s's hand is:\n\n%s"},
+	"bjduel.bust":         {Other: " - bust!"},
+	"bjduel.your_hand":    {Other: "Your hand is:\n\n%s"},
+	"bjduel.you_finished": {Other: "\n\nYou've finished your hand."},
+	"bjduel.hand_button":  {Other: "My hand"},
+	"bjduel.accepts":      {Other: "%s accepts! Dealing the cards..."},
+	"bjduel.thread":       {Other: "Blackjack duel: %s vs %s"},
+	"bjduel.channel_busy": {Other: "There's already a blackjack duel being played here! Both stakes have been returned."},
+	"bjduel.idle":         {Other: "%s took too long and stood."},
+	"bjduel.not_playing":  {Other: "You aren't playing in this duel."},
+	"bjduel.cant":         {Other: "You can't do that: %s."},
+
+	"poker.error.seated":        {Other: "you're already sitting at this table"},
+	"poker.error.full":          {Other: "the table is full"},
+	"poker.error.buy_in":        {Other: "you need %d chips to buy in, and your current balance is: %d"},
+	"poker.error.leave_in_hand": {Other: "you can't leave in the middle of a hand you're playing in. Wait for the hand to finish"},
+	"poker.error.not_seated":    {Other: "you aren't sitting at this table"},
+	"poker.error.in_hand":       {Other: "a hand is already being played"},
+	"poker.error.two_players":   {Other: "at least two players with chips are needed to deal a hand"},
+	"poker.error.no_hand":       {Other: "there's no hand being played. Press Deal to start one"},
+	"poker.error.turn":          {Other: "it's %s's turn"},
+	"poker.error.reopen":        {Other: "the last all in wasn't a full raise, so you can only call or fold"},
+	"poker.error.stack":         {Other: "you only have %d chips to bet"},
+	"poker.error.raise_more":    {Other: "you have to raise to more than the current bet of %d"},
+	"poker.error.min_raise":     {Other: "the smallest raise is to %d"},
+	"poker.error.action":        {Other: "%q isn't a poker action"},
+
+	"poker.new_hand":      {Other: "New hand! %s posts the small blind of %d and %s posts the big blind of %d."},
+	"poker.folds":         {Other: "%s folds."},
+	"poker.checks":        {Other: "%s checks."},
+	"poker.calls":         {Other: "%s calls %d."},
+	"poker.calls_all_in":  {Other: "%s calls all in for %d."},
+	"poker.raises":        {Other: "%s raises to %d."},
+	"poker.raises_all_in": {Other: "%s raises to %d and is all in!"},
+	"poker.bets":          {Other: "%s bets %d."},
+	"poker.bets_all_in":   {Other: "%s bets %d and is all in!"},
+	"poker.dealt.flop":    {Other: "The flop is dealt.\n"},
+	"poker.dealt.turn":    {Other: "The turn is dealt.\n"},
+	"poker.dealt.river":   {Other: "The river is dealt.\n"},
+	"poker.rake":          {Other: "The house takes a rake of %d.\n"},
+	"poker.shows":         {Other: "%s shows %s: %s\n"},
+	"poker.pot":           {Other: "the pot"},
+	"poker.main_pot":      {Other: "the main pot"},
+	"poker.side_pot":      {Other: "side pot %d"},
+	"poker.and":           {Other: " and "},
+	"poker.wins":          {Other: "%s wins %s of %d!\n"},
+	"poker.title":         {Other: "**Texas Hold'em** (blinds %d/%d, buy in %d)\n\n"},
+	"poker.no_board":      {Other: "none yet"},
+	"poker.board":         {Other: "Board: %s\nPot: %d\n\n"},
+	"poker.seat":          {Other: "%s: %d chips"},
+	"poker.button":        {Other: " (button)"},
+	"poker.sitting_out":   {Other: ", sitting out"},
+	"poker.folded":        {Other: ", folded"},
+	"poker.all_in_for":    {Other: ", all in for %d"},
+	"poker.bet":           {Other: ", bet %d"},
+	"poker.turn":          {Other: "\nIt's <@%s>'s turn."},
+	"poker.between_hands": {Other: "\nPress Join to sit down, and Deal to start the next hand."},
+	"poker.check_button":  {Other: "Check"},
+	"poker.call_button":   {Other: "Call %d"},
+	"poker.fold_button":   {Other: "Fold"},
+	"poker.raise_button":  {Other: "Raise"},
+	"poker.all_in_button": {Other: "All in"},
+	"poker.cards_button":  {Other: "My cards"},
+	"poker.join_button":   {Other: "Join"},
+	"poker.leave_button":  {Other: "Leave"},
+	"poker.deal_button":   {Other: "Deal"},
+	"poker.idle":          {Other: "%s took too long to act. "},
+
+	"poker.min_buy_in":        {Other: "The buy in should be at least 10 big blinds, which is %d."},
+	"poker.not_enough_chips":  {Other: "You don't have enough chips to buy in! Your current balance is: %d"},
+	"poker.cant_open":         {Other: "You can't open a table: %s."},
+	"poker.sits":              {Other: "%s sits down with %d chips."},
+	"poker.opening":           {Other: "%s is opening a Texas Hold'em table! Buy in is %d, blinds are %d/%d."},
+	"poker.thread":            {Other: "Hold'em with %s"},
+	"poker.cant_join":         {Other: "You can't join: %s."},
+	"poker.cant_leave":        {Other: "You can't leave: %s."},
+	"poker.leaves":            {Other: "%s leaves the table with %d chips."},
+	"poker.closed":            {Other: "Everyone has left, so the table is closed."},
+	"poker.only_players_deal": {Other: "Only players sitting at the table can deal."},
+	"poker.cant_deal":         {Other: "You can't deal yet: %s."},
+	"poker.no_cards":          {Other: "You don't have any cards in this hand."},
+	"poker.your_cards":        {Other: "Your cards are: %s"},
+	"poker.best_hand":         {Other: "\nYour best hand is: %s"},
+	"poker.not_your_turn":     {Other: "It isn't your turn."},
+	"poker.raise_placeholder": {Other: "At least %d, at most %d"},
+	"poker.raise_to":          {Other: "Raise to"},
+	"poker.table_closed":      {Other: "This table is closed."},
+	"poker.raise_number":      {Other: "The amount to raise to has to be a whole number."},
+	"poker.cant":              {Other: "You can't do that: %s."},
+
+	"card.ace":   {One: "Ace", Other: "Aces"},
+	"card.two":   {One: "Two", Other: "Twos"},
+	"card.three": {One: "Three", Other: "Threes"},
+	"card.four":  {One: "Four", Other: "Fours"},
+	"card.five":  {One: "Five", Other: "Fives"},
+	"card.six":   {One: "Six", Other: "Sixes"},
+	"card.seven": {One: "Seven", Other: "Sevens"},
+	"card.eight": {One: "Eight", Other: "Eights"},
+	"card.nine":  {One: "Nine", Other: "Nines"},
+	"card.ten":   {One: "Ten", Other: "Tens"},
+	"card.jack":  {One: "Jack", Other: "Jacks"},
+	"card.queen": {One: "Queen", Other: "Queens"},
+	"card.king":  {One: "King", Other: "Kings"},
+
+	"strategy.hit":                  {Other: "Hit"},
+	"strategy.stand":                {Other: "Stand"},
+	"strategy.double":               {Other: "Double down"},
+	"strategy.split":                {Other: "Split"},
+	"strategy.unknown":              {Other: "Unknown"},
+	"strategy.hand.hard":            {Other: "a hard %d"},
+	"strategy.hand.soft":            {Other: "a soft %d"},
+	"strategy.hand.pairs":           {Other: "a pair of %s"},
+	"strategy.advice":               {Other: "With %s against the dealer's %s, you should **%s**.\n\n"},
+	"strategy.ev_header":            {Other: "Expected chips won per chip wagered:\n"},
+	"strategy.not_available":        {Other: " (not available)"},
+	"strategy.even_better":          {Other: "\n\n%s would be even better, but it isn't available in this game."},
+	"strategy.reason.strong":        {Other: "Your hand is already strong, and another card is too likely to bust it."},
+	"strategy.reason.weak_upcard":   {Other: "The dealer's upcard is weak, so let them take the risk of busting instead of you."},
+	"strategy.reason.bust_risk":     {Other: "Taking another card busts too often to be worth it, even though your hand is weak."},
+	"strategy.reason.soft":          {Other: "Your ace can go back to counting as 1, so another card can't bust you and can only improve the hand."},
+	"strategy.reason.cant_bust":     {Other: "No card can bust you, so there is nothing to lose by taking another."},
+	"strategy.reason.strong_upcard": {Other: "The dealer is likely to end up with a strong hand, so standing here loses more often than risking a bust."},
+	"strategy.reason.double":        {Other: "Your hand is likely to improve with one more card, so it's worth putting more chips on it."},
+	"strategy.reason.split":         {Other: "Two hands starting with one of these cards each do better than the pair does together."},
+
+	"trainer.decision_correct": {Other: "Correct! %s is the right play here.\n\n"},
+	"trainer.decision_mistake": {Other: "Mistake! Basic strategy says to **%s** here, not %s.\n%s\n\n"},
+
+	"blackjack.hand_value": {Other: "\n\nThe value is: %d"},
+
+	"roulette_table.type.red":          {Other: "Red"},
+	"roulette_table.type.black":        {Other: "Black"},
+	"roulette_table.type.odd":          {Other: "Odd"},
+	"roulette_table.type.even":         {Other: "Even"},
+	"roulette_table.type.low":          {Other: "Low (1-18)"},
+	"roulette_table.type.high":         {Other: "High (19-36)"},
+	"roulette_table.type.dozen_1":      {Other: "1st dozen"},
+	"roulette_table.type.dozen_2":      {Other: "2nd dozen"},
+	"roulette_table.type.dozen_3":      {Other: "3rd dozen"},
+	"roulette_table.type.column_1":     {Other: "1st column"},
+	"roulette_table.type.column_2":     {Other: "2nd column"},
+	"roulette_table.type.column_3":     {Other: "3rd column"},
+	"roulette_table.modal_placeholder": {Other: "straight 17 5, split 17-20 5, corner 1-2-4-5 5..."},
+}
@@ -0,0 +1,578 @@
+// This file is the Spanish message catalog. It must have every key the English catalog has, which is checked when the
+// bot starts.
+package main
+
+// SpanishCatalog the Spanish messages, by key
+var SpanishCatalog = Catalog{
+	"language.name":          {Other: "español"},
+	"language.user_set":      {Other: "A partir de ahora te hablaré en %s."},
+	"language.user_reset":    {Other: "A partir de ahora te hablaré en el idioma del servidor, o en el idioma de tu Discord."},
+	"language.guild_set":     {Other: "El idioma de este servidor ahora es %s. Cada miembro puede elegir el suyo con /language me."},
+	"language.guild_reset":   {Other: "Este servidor ya no tiene un idioma, así que usaré el idioma de Discord de cada miembro."},
+	"language.no_permission": {Other: "Necesitas el permiso Gestionar servidor para cambiar el idioma del servidor."},
+
+	"balance": {Other: "%s, tu total de fichas es: %d"},
+
+	"leaderboard.title.wins":  {Other: "CLASIFICACIÓN DE VICTORIAS"},
+	"leaderboard.title.chips": {Other: "CLASIFICACIÓN DE FICHAS"},
+	"leaderboard.rank":        {Other: "PUESTO"},
+	"leaderboard.player":      {Other: "JUGADOR"},
+	"leaderboard.wins":        {Other: "VICTORIAS"},
+	"leaderboard.ties":        {Other: "EMPATES"},
+	"leaderboard.losses":      {Other: "DERROTAS"},
+	"leaderboard.chips":       {Other: "FICHAS"},
+
+	"game.not_enough_chips": {Other: "¡No tienes suficientes fichas para esa apuesta! Tu saldo actual es: %d"},
+	"game.in_game":          {Other: "¡Ya estás jugando una partida en otro sitio! Termina esa partida primero."},
+	"game.in_game_force":    {Other: "¡Ya estás jugando una partida en otro sitio! Termina esa partida primero, o usa la opción force para empezar una nueva."},
+	"game.forfeit": {
+		One:   "¡Terminando tu otra partida para empezar una nueva! Perdiste tu apuesta anterior de %d ficha.",
+		Other: "¡Terminando tu otra partida para empezar una nueva! Perdiste tu apuesta anterior de %d fichas.",
+	},
+	"game.channel_ours": {Other: "¡Ya estamos jugando una partida aquí!"},
+	"game.channel_busy": {Other: "Estoy jugando una partida con %s en este canal. Por favor, prueba en otro canal."},
+
+	"blackjack.starting":         {Other: "¡Empezando una nueva partida de blackjack con %s!"},
+	"blackjack.trainer_starting": {Other: "¡Empezando una partida de entrenamiento de blackjack con %s! No hay fichas en juego, te avisaré si cometes un error."},
+	"blackjack.title":            {Other: "Blackjack con %s"},
+	"blackjack.trainer_title":    {Other: "Entrenamiento de blackjack con %s"},
+	"blackjack.hit":              {Other: "¡Pides carta! "},
+	"blackjack.stand":            {Other: "¡Te plantas!"},
+	"blackjack.hit_or_stand":     {Other: "¿Pides carta o te plantas?"},
+	"blackjack.twenty_one":       {Other: "¡21! Es el turno de la banca."},
+	"blackjack.bust":             {Other: "¡Vaya, te pasaste!"},
+	"blackjack.hit_button":       {Other: "Pedir"},
+	"blackjack.stand_button":     {Other: "Plantarse"},
+	"blackjack.hint_button":      {Other: "Pista"},
+	"blackjack.dealer_wins":      {Other: "Gana la banca."},
+	"blackjack.draw":             {Other: "¡Es un empate!"},
+	"blackjack.player_wins":      {Other: "¡%s gana!"},
+	"blackjack.dealer_hand":      {Other: "Mano de la banca"},
+	"blackjack.player_hand":      {Other: "Mano de %s"},
+	"blackjack.showing":          {Other: "Muestra %s"},
+	"blackjack.value":            {Other: "Valor: %d"},
+	"blackjack.wager":            {Other: "Apuesta"},
+	"blackjack.no_stake":         {Other: "Sin fichas en juego"},
+	"blackjack.balance":          {Other: "Saldo"},
+	"blackjack.game_id":          {Other: "ID de la partida: %s"},
+	"blackjack.trainer_result":   {Other: " No había fichas en juego."},
+	"blackjack.trainer_decisions": {
+		One:   "Tomaste %d de %d decisión correctamente en esta mano.",
+		Other: "Tomaste %d de %d decisiones correctamente en esta mano.",
+	},
+
+	"net.returned": {Other: " Se te devolvió la apuesta."},
+	"net.gained": {
+		One:   " ¡Ganaste %d ficha!",
+		Other: " ¡Ganaste %d fichas!",
+	},
+	"net.lost": {
+		One:   " Perdiste %d ficha.",
+		Other: " Perdiste %d fichas.",
+	},
+	"net.pity":  {Other: "\n\n¡Vaya, parece que perdiste tus últimas fichas! Volveré a poner tu total en %d para que puedas seguir jugando."},
+	"net.total": {Other: "\n\nTu nuevo total de fichas es: %d"},
+
+	"reward.claimed.daily":   {Other: "¡Reclamaste tus %d fichas diarias!"},
+	"reward.claimed.hourly":  {Other: "¡Reclamaste tus %d fichas de cada hora!"},
+	"reward.streak":          {Other: " Llevas una racha de %d días, que te dio %d fichas extra. ¡Vuelve mañana para mantenerla!"},
+	"reward.cooldown.daily":  {Other: "Ya reclamaste tus fichas diarias. Podrás reclamarlas de nuevo <t:%d:R>."},
+	"reward.cooldown.hourly": {Other: "Ya reclamaste tus fichas de cada hora. Podrás reclamarlas de nuevo <t:%d:R>."},
+
+	"give.self": {Other: "¡Tienes que darle fichas a otro miembro!"},
+	"give.account_too_new": {
+		One:   "Tu cuenta de Discord tiene que tener al menos %d día para poder dar fichas.",
+		Other: "Tu cuenta de Discord tiene que tener al menos %d días para poder dar fichas.",
+	},
+	"give.limit":          {Other: "Puedes dar hasta %d fichas al día, y ahora mismo solo puedes dar %d más."},
+	"give.limit_reached":  {Other: "Esa transferencia superaría el límite diario de %d fichas, así que no se hizo."},
+	"give.confirm":        {Other: "¿Dar %d fichas a %s? La casa se queda %d de impuesto, así que recibirá %d."},
+	"give.confirm_button": {Other: "Dar"},
+	"give.cancel_button":  {Other: "Cancelar"},
+	"give.expired":        {Other: "La transferencia no se confirmó a tiempo, así que no se dieron fichas."},
+	"give.cancelled":      {Other: "La transferencia se canceló, así que no se dieron fichas."},
+	"give.sent":           {Other: "¡Le diste %d fichas a %s!"},
+	"give.announce":       {Other: "<@%s>, ¡%s te dio %d fichas!"},
+
+	"loan.taken":            {Other: "¡Pediste prestadas %d fichas! Cada día se suma un %.1f%% de interés, y el préstamo vence <t:%d:R>."},
+	"loan.over_limit":       {Other: "Puedes pedir prestadas hasta %d fichas."},
+	"loan.outstanding":      {Other: "¡Ya tienes un préstamo! Págalo con /loan repay antes de pedir otro."},
+	"loan.defaulted":        {Other: "Tu préstamo está en mora, así que no puedes pedir otro hasta que lo pagues."},
+	"loan.none":             {Other: "No tienes ningún préstamo."},
+	"loan.credit":           {Other: " Puedes pedir prestadas hasta %d fichas."},
+	"loan.status":           {Other: "Debes %d fichas, que vencen <t:%d:R>. Cada día se suma un %.1f%% de interés hasta que lo pagues."},
+	"loan.status_defaulted": {Other: "Debes %d fichas, que vencieron <t:%d:R>. Tu préstamo está en mora, así que no puedes pedir otro hasta que lo pagues."},
+	"loan.repaid":           {Other: "Pagaste %d fichas de tu préstamo. Todavía debes %d."},
+	"loan.repaid_all":       {Other: "¡Pagaste %d fichas y tu préstamo está saldado!"},
+	"loan.auto_repaid":      {Other: "\n\n%d fichas de tus ganancias se usaron para pagar tu préstamo. Todavía debes %d."},
+	"loan.auto_repaid_all":  {Other: "\n\n¡%d fichas de tus ganancias saldaron tu préstamo!"},
+
+	"profile.title":          {Other: "Perfil de %s"},
+	"profile.chips":          {Other: "%d fichas"},
+	"profile.record":         {Other: "Historial"},
+	"profile.record_value":   {Other: "%d victorias, %d empates, %d derrotas"},
+	"profile.loan_title":     {Other: "Préstamo"},
+	"profile.no_loan":        {Other: "Ninguno"},
+	"profile.loan":           {Other: "%d fichas, vence <t:%d:R>"},
+	"profile.loan_defaulted": {Other: "%d fichas, **en mora** desde <t:%d:R>"},
+	"profile.credit":         {Other: "Límite de crédito"},
+	"profile.defaults":       {Other: "Historial de préstamos"},
+	"profile.defaults_value": {Other: "%d pagados, %d en mora"},
+
+	"baccarat.player":     {Other: "Jugador"},
+	"baccarat.banker":     {Other: "Banca"},
+	"baccarat.tie":        {Other: "Empate"},
+	"baccarat.bets":       {Other: "%s apuesta %d a %s.\n\n"},
+	"baccarat.hand":       {Other: "%s: %s (%d)\n"},
+	"baccarat.tie_result": {Other: "¡Es un empate!"},
+	"baccarat.wins":       {Other: "¡Gana %s!"},
+	"baccarat.bead_road":  {Other: "\n\nHistorial:\n"},
+
+	"war.playing":          {Other: "%s juega a la guerra por %d fichas.\n\n"},
+	"war.burned":           {Other: "\n**¡Guerra!** Se queman %d cartas.\n"},
+	"war.player_card":      {Other: "Carta de %s: %s\n"},
+	"war.dealer_card":      {Other: "Carta del crupier: %s\n"},
+	"war.go_button":        {Other: "Ir a la guerra (subir %d)"},
+	"war.surrender_button": {Other: "Rendirse (perder %d)"},
+	"war.in_game":          {Other: "¡Ya estás en guerra! Ve a la guerra o ríndete en esa partida primero."},
+	"war.tie":              {Other: "¡Es un empate! ¿Vas a la guerra, o te rindes y pierdes la mitad de tu apuesta?"},
+	"war.win":              {Other: "Tu carta es más alta, ¡ganas!"},
+	"war.lose":             {Other: "La carta del crupier es más alta, pierdes."},
+	"war.not_yours":        {Other: "Esta partida de guerra no es tuya."},
+	"war.raise_short":      {Other: "Necesitas otras %d fichas para ir a la guerra, y tu saldo actual es: %d"},
+	"war.war_win":          {Other: "Tu carta es al menos tan alta como la del crupier, ¡ganas la subida!"},
+	"war.war_lose":         {Other: "La carta del crupier es más alta, pierdes tu apuesta y la subida."},
+	"war.surrendered":      {Other: "Te rindes y pierdes la mitad de tu apuesta."},
+
+	"keno.error.number": {Other: "%q no es un número del 1 al %d"},
+	"keno.error.repeat": {Other: "el %d está elegido más de una vez"},
+	"keno.error.spots":  {Other: "elige de 1 a %d números"},
+	"keno.bad_numbers":  {Other: "Esos números no sirven: %s."},
+	"keno.bets":         {Other: "%s apuesta %d al keno.\n\n"},
+	"keno.numbers":      {Other: "Tus números: %s\n"},
+	"keno.drawn":        {Other: "Sorteados: %s\n\n"},
+	"keno.caught":       {Other: "Acertaste %d de %d"},
+	"keno.pays":         {Other: ", ¡que paga %d!"},
+
+	"jackpot.announce":      {Other: "¡BOTE! %s consiguió %s y ganó %d fichas del bote."},
+	"jackpot.won":           {Other: "\n\n¡BOTE! Conseguiste %s y ganaste %d fichas del bote. Tu total de fichas ahora es: %d"},
+	"jackpot.pool":          {Other: "¡El bote está en %d fichas! Consigue tres sietes en el blackjack, o una línea del mejor símbolo en la tragaperras, para ganarlo. ¡Tres sietes del mismo palo en el blackjack se llevan el bote entero!"},
+	"jackpot.reason.sevens": {Other: "tres sietes"},
+	"jackpot.reason.slots":  {Other: "una línea de %s"},
+
+	"slots.closed":           {Other: "La tragaperras está cerrada ahora mismo."},
+	"slots.not_enough_chips": {Other: "¡No tienes suficientes fichas para esa apuesta! %d en cada una de las %d líneas son %d, y tu saldo actual es: %d"},
+	"slots.title":            {Other: "%s apuesta %d en %d líneas y tira de la palanca...\n\n"},
+	"slots.line_wins":        {Other: "¡La línea %d gana %d!\n"},
+	"slots.no_wins":          {Other: "Ninguna línea ganadora."},
+
+	"roulette.wheel.european":    {Other: "europea"},
+	"roulette.wheel.american":    {Other: "americana"},
+	"roulette.colour.red":        {Other: "rojo"},
+	"roulette.colour.black":      {Other: "negro"},
+	"roulette.colour.green":      {Other: "verde"},
+	"roulette.ordinal.1":         {Other: "1.º"},
+	"roulette.ordinal.2":         {Other: "2.º"},
+	"roulette.ordinal.3":         {Other: "3.º"},
+	"roulette.bet":               {Other: "%s por %d"},
+	"roulette.bet_on":            {Other: "%s %s por %d"},
+	"roulette.bet_wins":          {Other: "%s gana %d\n"},
+	"roulette.bet_loses":         {Other: "%s pierde\n"},
+	"roulette.bad_bets":          {Other: "No entendí tus apuestas: %s."},
+	"roulette.cant_place":        {Other: "No pude hacer tus apuestas: %s."},
+	"roulette.cant_settle":       {Other: "No pude liquidar tus apuestas: %s."},
+	"roulette.not_enough_chips":  {Other: "¡No tienes suficientes fichas para esas apuestas! Suman %d, y tu saldo actual es: %d"},
+	"roulette.spins":             {Other: "%s gira la ruleta %s...\n\n"},
+	"roulette.lands":             {Other: "¡La bola cae en el **%[2]s %[1]s**!\n\n"},
+	"roulette.player_wins":       {Other: "¡Gana %s!"},
+	"roulette.house_wins":        {Other: "Gana la casa."},
+	"roulette.even":              {Other: "¡Quedaste igual!"},
+	"roulette.error.double_zero": {Other: "el 00 solo está en la ruleta americana"},
+	"roulette.error.pocket":      {Other: "%q no es un número de la ruleta"},
+	"roulette.error.too_short":   {Other: "%q necesita un tipo de apuesta y una cantidad"},
+	"roulette.error.amount":      {Other: "%q no termina con una cantidad de fichas válida"},
+	"roulette.error.too_many":    {Other: "%q son más fichas de las que tienes"},
+	"roulette.error.outside":     {Other: "%q debería ser solo el tipo de apuesta y la cantidad"},
+	"roulette.error.inside":      {Other: "%q debería ser el tipo de apuesta, los números y luego la cantidad"},
+	"roulette.error.which":       {Other: "%q debería elegir la 1.ª, 2.ª o 3.ª %s"},
+	"roulette.error.straight":    {Other: "%q debería ser a un solo número"},
+	"roulette.error.split":       {Other: "%q debería ser a dos números contiguos"},
+	"roulette.error.zero":        {Other: "%q no puede incluir un cero"},
+	"roulette.error.street":      {Other: "%q debería ser una fila de tres números, como 4-5-6"},
+	"roulette.error.corner":      {Other: "%q debería ser cuatro números en cuadro, como 1-2-4-5"},
+	"roulette.error.line":        {Other: "%q debería ser dos filas contiguas, como 1-6"},
+	"roulette.error.type":        {Other: "%q no es un tipo de apuesta"},
+	"roulette.error.no_bets":     {Other: "no hiciste ninguna apuesta"},
+	"roulette.error.overflow":    {Other: "esas apuestas suman más fichas de las que puedo contar"},
+
+	"roulette_table.closed":  {Other: "La mesa de ruleta %s está cerrada.\n\n"},
+	"roulette_table.open":    {Other: "¡La mesa de ruleta %s está abierta! Las apuestas se cierran <t:%d:R>.\nElige una apuesta y una cantidad y pulsa Apostar, o pulsa Apuesta interior para escribir una apuesta a números.\n\n"},
+	"roulette_table.no_bets": {Other: "Todavía no hay apuestas."},
+	"roulette_table.no_spin": {Other: "Las apuestas están cerradas. Nadie apostó, así que la ruleta no gira."},
+	"roulette_table.spins":   {Other: "¡Las apuestas están cerradas! La ruleta gira...\n\n"},
+	"roulette_table.result":  {Other: "**%s**: %+d fichas (ahora %d)\n"},
+	"roulette_table.chips": {
+		One:   "%d ficha",
+		Other: "%d fichas",
+	},
+	"roulette_table.pick_bet":               {Other: "Elige una apuesta"},
+	"roulette_table.pick_amount":            {Other: "Elige una cantidad"},
+	"roulette_table.place_button":           {Other: "Apostar"},
+	"roulette_table.inside_button":          {Other: "Apuesta interior"},
+	"roulette_table.clear_button":           {Other: "Quitar mis apuestas"},
+	"roulette_table.already_open":           {Other: "¡Ya hay una mesa de ruleta abierta en este canal! Haz tus apuestas allí."},
+	"roulette_table.pick_first":             {Other: "¡Primero elige una apuesta y una cantidad en los menús!"},
+	"roulette_table.cant_place":             {Other: "No pude hacer esa apuesta: %s."},
+	"roulette_table.modal_title":            {Other: "Haz una apuesta interior"},
+	"roulette_table.modal_label":            {Other: "Apuesta"},
+	"roulette_table.betting_closed":         {Other: "Las apuestas de esta mesa están cerradas."},
+	"roulette_table.bad_bet":                {Other: "No entendí tu apuesta: %s."},
+	"roulette_table.cleared":                {Other: "Tus apuestas se han quitado de la mesa."},
+	"roulette_table.placed":                 {Other: "Apuesta hecha: %s."},
+	"roulette_table.error.closed":           {Other: "las apuestas están cerradas"},
+	"roulette_table.error.not_enough_chips": {Other: "no tienes suficientes fichas para esa apuesta. Tu saldo actual es: %d"},
+
+	"crash.error.no_bet":      {Other: "no tienes ninguna apuesta en esta ronda"},
+	"crash.error.not_started": {Other: "el multiplicador todavía no ha empezado a subir"},
+	"crash.error.cashed_out":  {Other: "ya cobraste a %.2fx"},
+	"crash.error.too_late":    {Other: "demasiado tarde, se estrelló a %.2fx"},
+	"crash.crashed":           {Other: "💥 **¡SE ESTRELLÓ a %.2fx!**\n\n"},
+	"crash.starting":          {Other: "¡Una ronda de crash empieza en %d segundos! Usa /crash para apostar.\n\n"},
+	"crash.bet_cashed_out":    {Other: "%s cobró %d a %.2fx\n"},
+	"crash.bet_lost":          {Other: "%s perdió %d\n"},
+	"crash.bet_in":            {Other: "%s entra con %d\n"},
+	"crash.seeds":             {Other: "\nHash de la semilla del servidor: `%s`\nSemilla del cliente: `%s`"},
+	"crash.server_seed":       {Other: "\nSemilla del servidor: `%s`"},
+	"crash.cash_out_button":   {Other: "Cobrar"},
+	"crash.pity":              {Other: "%s perdió sus últimas fichas, así que le he vuelto a dar %d."},
+	"crash.already_started":   {Other: "¡Esta ronda ya ha empezado! Espera a que se estrelle y apuesta en la siguiente."},
+	"crash.already_bet":       {Other: "Ya has apostado en esta ronda."},
+	"crash.bets":              {Other: "%s apuesta %d al crash."},
+	"crash.over":              {Other: "Esta ronda ha terminado."},
+	"crash.cant_cash_out":     {Other: "No puedes cobrar: %s."},
+	"crash.cashed_out":        {Other: "¡Cobraste a %.2fx!"},
+
+	"hilo.playing":         {Other: "%s juega a mayor o menor por %d fichas.\n\n"},
+	"hilo.previous":        {Other: "Cartas anteriores: %s\n"},
+	"hilo.current":         {Other: "Carta actual: **%s**\n\n"},
+	"hilo.multiplier":      {Other: "Multiplicador: **%.2fx**"},
+	"hilo.higher_button":   {Other: "Mayor (%.2fx)"},
+	"hilo.lower_button":    {Other: "Menor (%.2fx)"},
+	"hilo.cash_out_button": {Other: "Cobrar (%d)"},
+	"hilo.wrong":           {Other: "¡Fallaste! %s pierde."},
+	"hilo.cashed_out":      {Other: "¡%s cobró a %.2fx y se lleva %d fichas!"},
+	"hilo.in_game":         {Other: "¡Ya estás jugando a mayor o menor! Termina esa partida primero."},
+	"hilo.first_guess":     {Other: "¿La siguiente carta será mayor o menor?"},
+	"hilo.not_yours":       {Other: "Esta partida de mayor o menor no es tuya."},
+	"hilo.guess_first":     {Other: "Haz al menos una predicción antes de cobrar."},
+	"hilo.cant_win":        {Other: "¡Esa predicción no puede ganar!"},
+	"hilo.right":           {Other: "¡Acertaste! ¿Mayor o menor?"},
+	"hilo.push":            {Other: "Mismo valor, ¡es un empate! ¿Mayor o menor?"},
+	"hilo.last_card":       {Other: "¡Esa era la última carta! "},
+
+	"mines.playing":         {Other: "%s juega a las minas con %d minas por %d fichas.\n"},
+	"mines.multiplier":      {Other: "Multiplicador: **%.2fx**"},
+	"mines.next_tile":       {Other: ", siguiente casilla: %.2fx"},
+	"mines.hash":            {Other: "\nHash del tablero: `%s`"},
+	"mines.layout":          {Other: "\nSal: `%s`\nMinas: `%s`"},
+	"mines.boom":            {Other: "💥 ¡Bum! ¡%s pisó una mina!"},
+	"mines.cashed_out":      {Other: "¡%s cobró a %.2fx y se lleva %d fichas!"},
+	"mines.in_game":         {Other: "¡Ya estás jugando a las minas! Termina esa partida primero."},
+	"mines.cash_out_prompt": {Other: "¡Cobra cuando quieras!"},
+	"mines.cash_out_button": {Other: "Cobrar"},
+	"mines.not_yours":       {Other: "Esta partida de minas no es tuya."},
+	"mines.over":            {Other: "Esta partida ha terminado."},
+	"mines.reveal_first":    {Other: "Descubre al menos una casilla antes de cobrar."},
+
+	"poker.high_card":       {Other: "Carta alta"},
+	"poker.one_pair":        {Other: "Pareja"},
+	"poker.two_pair":        {Other: "Doble pareja"},
+	"poker.three_of_a_kind": {Other: "Trío"},
+	"poker.straight":        {Other: "Escalera"},
+	"poker.flush":           {Other: "Color"},
+	"poker.full_house":      {Other: "Full"},
+	"poker.four_of_a_kind":  {Other: "Póquer"},
+	"poker.straight_flush":  {Other: "Escalera de color"},
+	"poker.royal_flush":     {Other: "Escalera real"},
+
+	"videopoker.playing":     {Other: "%s juega a Jacks or Better por %d fichas.\n\n"},
+	"videopoker.held":        {Other: " (guardada)"},
+	"videopoker.hold_button": {Other: "Guardar %d"},
+	"videopoker.draw_button": {Other: "Robar"},
+	"videopoker.in_game":     {Other: "¡Ya estás jugando una mano de video póquer! Roba en esa mano primero."},
+	"videopoker.hold_prompt": {Other: "Elige las cartas que quieres guardar y luego roba."},
+	"videopoker.not_yours":   {Other: "Esta mano de video póquer no es tuya."},
+	"videopoker.no_win":      {Other: "Sin premio."},
+	"videopoker.pays":        {Other: "¡%s paga %d!"},
+
+	"craps.bet.pass":     {Other: "Línea de pase"},
+	"craps.bet.dontpass": {Other: "No pase"},
+	"craps.bet.come":     {Other: "Venir"},
+	"craps.bet.dontcome": {Other: "No venir"},
+	"craps.bet.place":    {Other: "Número"},
+	"craps.bet.field":    {Other: "Campo"},
+
+	"craps.error.line_bet":    {Other: "las apuestas de línea solo se pueden hacer antes de la tirada de salida"},
+	"craps.error.come_bet":    {Other: "las apuestas de venir solo se pueden hacer cuando hay un punto. Apuesta a la línea de pase"},
+	"craps.error.place_bet":   {Other: "las apuestas a número solo pueden ser al 4, 5, 6, 8, 9 o 10"},
+	"craps.error.type":        {Other: "%q no es una apuesta de craps"},
+	"craps.error.line_odds":   {Other: "solo se pueden añadir probabilidades a una apuesta de línea cuando hay un punto"},
+	"craps.error.max_odds":    {Other: "las probabilidades pueden ser como mucho %d veces la apuesta, es decir, %d fichas más"},
+	"craps.error.no_line_bet": {Other: "no tienes una apuesta de línea a la que añadir probabilidades"},
+	"craps.error.no_come_bet": {Other: "no tienes una apuesta de venir al %d a la que añadir probabilidades"},
+
+	"craps.point_off":        {Other: "No hay punto. La siguiente tirada es de salida.\n"},
+	"craps.point":            {Other: "El punto es %d.\n"},
+	"craps.no_shooter":       {Other: "Nadie tiene los dados. Cualquiera con una apuesta puede tirar.\n"},
+	"craps.shooter":          {Other: "%s es el tirador.\n"},
+	"craps.no_bets":          {Other: "\nNo hay apuestas en la mesa."},
+	"craps.player":           {Other: "JUGADOR"},
+	"craps.bet":              {Other: "APUESTA"},
+	"craps.amount":           {Other: "CANTIDAD"},
+	"craps.odds":             {Other: "PROBABILIDADES"},
+	"craps.not_enough_chips": {Other: "¡No tienes suficientes fichas para esa apuesta! Tu saldo actual es: %d"},
+	"craps.cant_bet":         {Other: "No puedes hacer esa apuesta: %s."},
+	"craps.bets":             {Other: "%s apuesta %d a %s.\n\n%s"},
+	"craps.need_bet":         {Other: "Necesitas una apuesta en la mesa para tirar. ¡Empieza con una apuesta a la línea de pase!"},
+	"craps.not_shooter":      {Other: "Solo el tirador puede tirar los dados. Ahora tira %s."},
+	"craps.rolls":            {Other: "¡%s saca %d y %d, **%d** en total!\n"},
+	"craps.seven_out":        {Other: "¡Siete fuera!"},
+	"craps.dice_pass":        {Other: " Los dados pasan a %s."},
+	"craps.made_point":       {Other: "¡El tirador ha hecho el punto!\n"},
+	"craps.wins":             {Other: "%s gana %d en %s."},
+	"craps.loses":            {Other: "%s pierde %d en %s."},
+	"craps.pity":             {Other: "%s perdió sus últimas fichas, así que le he vuelto a dar %d."},
+
+	"lottery.no_tickets": {Other: "Se ha celebrado el sorteo de la lotería, pero esta vez nadie compró un boleto."},
+	"lottery.drawn":      {Other: "¡Se ha celebrado el sorteo de la lotería! ¡%s gana el bote de %d fichas!"},
+	"lottery.next_draw":  {Other: " El próximo sorteo es <t:%d:R>."},
+	"lottery.not_enough_chips": {
+		One:   "%d boleto cuesta %d fichas y tu saldo actual es: %d",
+		Other: "%d boletos cuestan %d fichas y tu saldo actual es: %d",
+	},
+	"lottery.drawing": {Other: "¡El sorteo de la lotería se está celebrando ahora mismo! Inténtalo de nuevo en un momento."},
+	"lottery.bought": {
+		One:   "¡%s compra %d boleto de lotería por %d fichas! Tu total de fichas ahora es: %d\n\n",
+		Other: "¡%s compra %d boletos de lotería por %d fichas! Tu total de fichas ahora es: %d\n\n",
+	},
+	"lottery.status": {Other: "El próximo sorteo de la lotería es <t:%d:R>. Los boletos cuestan %d fichas cada uno.\n"},
+	"lottery.pot": {
+		One:   "El bote es de %d fichas de %d boleto.",
+		Other: "El bote es de %d fichas de %d boletos.",
+	},
+	"lottery.mine": {
+		One:   " Tienes %d boleto, un %.1f%% de probabilidad de ganar.",
+		Other: " Tienes %d boletos, un %.1f%% de probabilidad de ganar.",
+	},
+
+	"counting.reshuffled":     {Other: "Se ha barajado el zapato. La cuenta vuelve a empezar desde 0."},
+	"counting.running_answer": {Other: "Cuenta corrida: dijiste %d, era %d. %s\n"},
+	"counting.true_answer":    {Other: "Cuenta real: dijiste %d, era %.2f (quedan %.1f barajas). %s\n"},
+	"counting.answer_time":    {Other: "Respondiste en %.1f segundos."},
+	"counting.correct":        {Other: "¡Correcto!"},
+	"counting.wrong":          {Other: "Incorrecto."},
+	"counting.over_empty":     {Other: "Sesión de conteo terminada. No respondiste a ninguna comprobación, así que no se ha guardado nada."},
+	"counting.over":           {Other: "¡Sesión de conteo terminada!\n\nCuenta corrida correcta: %d de %d\nCuenta real correcta: %d de %d\nTiempo medio de respuesta: %.1f segundos"},
+	"counting.no_stats":       {Other: "Todavía no has terminado ninguna sesión de conteo de cartas. ¡Usa /count-trainer para empezar!"},
+	"counting.stats": {
+		One:   "Conteo de cartas: %d sesión, cuenta corrida %.1f%% correcta, cuenta real %.1f%% correcta, tiempo medio de respuesta %.1f segundos.",
+		Other: "Conteo de cartas: %d sesiones, cuenta corrida %.1f%% correcta, cuenta real %.1f%% correcta, tiempo medio de respuesta %.1f segundos.",
+	},
+	"counting.prompt":         {Other: "¿Cuál es la cuenta?"},
+	"counting.enter_button":   {Other: "Introducir cuenta"},
+	"counting.stop_button":    {Other: "Parar"},
+	"counting.in_session":     {Other: "¡Ya estás en una sesión de conteo! Para esa primero."},
+	"counting.starting":       {Other: "¡Empezando una sesión de conteo de cartas con %s! Repartiendo de un zapato de %d barajas, una carta cada %.1f segundos. Te pediré la cuenta Hi-Lo cada %d cartas."},
+	"counting.thread":         {Other: "Conteo de cartas con %s"},
+	"counting.running_label":  {Other: "Cuenta corrida"},
+	"counting.true_label":     {Other: "Cuenta real"},
+	"counting.running_number": {Other: "La cuenta corrida tiene que ser un número entero. ¡Inténtalo de nuevo!"},
+	"counting.true_number":    {Other: "La cuenta real tiene que ser un número entero. ¡Inténtalo de nuevo!"},
+
+	"trainer.no_stats":         {Other: "%s, todavía no has tomado ninguna decisión en el entrenador. ¡Usa /blackjack-trainer para empezar!"},
+	"trainer.accuracy":         {Other: "PRECISIÓN DE %s EN EL ENTRENADOR\n"},
+	"trainer.hands":            {Other: "MANOS"},
+	"trainer.correct":          {Other: "CORRECTAS"},
+	"trainer.total":            {Other: "TOTAL"},
+	"trainer.accuracy_column":  {Other: "PRECISIÓN"},
+	"trainer.category.hard":    {Other: "duras"},
+	"trainer.category.soft":    {Other: "blandas"},
+	"trainer.category.pairs":   {Other: "parejas"},
+	"trainer.category.overall": {Other: "total"},
+
+	"duel.heads":            {Other: "¡La moneda sale cara! %s eligió cara."},
+	"duel.tails":            {Other: "¡La moneda sale cruz! %s eligió cruz."},
+	"duel.rolls":            {Other: "%s saca %d, %s saca %d.\n"},
+	"duel.tie":              {Other: "¡Empate, tirad otra vez!\n"},
+	"duel.another_member":   {Other: "¡Tienes que desafiar a otro miembro!"},
+	"duel.not_enough_chips": {Other: "¡No tienes suficientes fichas para esa apuesta! Tu saldo actual es: %d"},
+	"duel.game.coin":        {Other: "lanzar una moneda, eligiendo cara,"},
+	"duel.game.dice":        {Other: "una tirada de dados"},
+	"duel.game.blackjack":   {Other: "una mano de blackjack"},
+	"duel.challenge":        {Other: "<@%s>, ¡%s te desafía a %s por %d fichas! Tienes %d minutos para aceptar."},
+	"duel.accept_button":    {Other: "Aceptar"},
+	"duel.decline_button":   {Other: "Rechazar"},
+	"duel.expired":          {Other: "El desafío de %s a %s no se aceptó a tiempo, así que se ha devuelto la apuesta."},
+	"duel.not_yours":        {Other: "¡Este desafío no es para ti!"},
+	"duel.cant_accept":      {Other: "¡No tienes suficientes fichas para aceptar! Tu saldo actual es: %d"},
+	"duel.draw":             {Other: "\n\n¡Es un empate! Se han devuelto las dos apuestas."},
+	"duel.wins":             {Other: "\n\n¡%s gana el bote de %d fichas! Su total de fichas ahora es: %d"},
+	"duel.pity":             {Other: "%s perdió sus últimas fichas, así que le he vuelto a dar %d."},
+	"duel.declined":         {Other: "%s rechazó el desafío."},
+	"duel.called_off":       {Other: "%s canceló el desafío."},
+	"duel.stake_returned":   {Other: " Se ha devuelto la apuesta."},
+
+	"bjduel.error.not_playing":   {Other: "no estás jugando en este duelo"},
+	"bjduel.error.finished":      {Other: "ya has terminado tu mano"},
+	"bjduel.error.not_your_turn": {Other: "no es tu turno"},
+	"bjduel.title":               {Other: "**Duelo de blackjack** por %d fichas cada uno\n\n"},
+	"bjduel.finished":            {Other: "%s ha terminado su mano.\n"},
+	"bjduel.cards": {
+		One:   "%s tiene %d carta.\n",
+		Other: "%s tiene %d cartas.\n",
+	},
+	"bjduel.turn":         {Other: "\nEs el turno de <@%s>. Las manos están ocultas hasta que los dos jugadores terminen, así que pulsa Mi mano para ver tus cartas y luego pide o plántate."},
+	"bjduel.hand":         {Other: "La mano de %s es:\n\n%s"},
+	"bjduel.bust":         {Other: " - ¡se pasa!"},
+	"bjduel.your_hand":    {Other: "Tu mano es:\n\n%s"},
+	"bjduel.you_finished": {Other: "\n\nHas terminado tu mano."},
+	"bjduel.hand_button":  {Other: "Mi mano"},
+	"bjduel.accepts":      {Other: "¡%s acepta! Repartiendo las cartas..."},
+	"bjduel.thread":       {Other: "Duelo de blackjack: %s contra %s"},
+	"bjduel.channel_busy": {Other: "¡Ya se está jugando un duelo de blackjack aquí! Se han devuelto las dos apuestas."},
+	"bjduel.idle":         {Other: "%s tardó demasiado y se plantó."},
+	"bjduel.not_playing":  {Other: "No estás jugando en este duelo."},
+	"bjduel.cant":         {Other: "No puedes hacer eso: %s."},
+
+	"poker.error.seated":        {Other: "ya estás sentado en esta mesa"},
+	"poker.error.full":          {Other: "la mesa está llena"},
+	"poker.error.buy_in":        {Other: "necesitas %d fichas para entrar y tu saldo actual es: %d"},
+	"poker.error.leave_in_hand": {Other: "no puedes irte en mitad de una mano en la que estás jugando. Espera a que termine la mano"},
+	"poker.error.not_seated":    {Other: "no estás sentado en esta mesa"},
+	"poker.error.in_hand":       {Other: "ya se está jugando una mano"},
+	"poker.error.two_players":   {Other: "hacen falta al menos dos jugadores con fichas para repartir una mano"},
+	"poker.error.no_hand":       {Other: "no se está jugando ninguna mano. Pulsa Repartir para empezar una"},
+	"poker.error.turn":          {Other: "es el turno de %s"},
+	"poker.error.reopen":        {Other: "el último all in no fue una subida completa, así que solo puedes igualar o retirarte"},
+	"poker.error.stack":         {Other: "solo tienes %d fichas para apostar"},
+	"poker.error.raise_more":    {Other: "tienes que subir a más de la apuesta actual de %d"},
+	"poker.error.min_raise":     {Other: "la subida mínima es a %d"},
+	"poker.error.action":        {Other: "%q no es una acción de póquer"},
+
+	"poker.new_hand":      {Other: "¡Nueva mano! %s pone la ciega pequeña de %d y %s pone la ciega grande de %d."},
+	"poker.folds":         {Other: "%s se retira."},
+	"poker.checks":        {Other: "%s pasa."},
+	"poker.calls":         {Other: "%s iguala %d."},
+	"poker.calls_all_in":  {Other: "%s iguala con todo por %d."},
+	"poker.raises":        {Other: "%s sube a %d."},
+	"poker.raises_all_in": {Other: "¡%s sube a %d y va con todo!"},
+	"poker.bets":          {Other: "%s apuesta %d."},
+	"poker.bets_all_in":   {Other: "¡%s apuesta %d y va con todo!"},
+	"poker.dealt.flop":    {Other: "Se reparte el flop.\n"},
+	"poker.dealt.turn":    {Other: "Se reparte el turn.\n"},
+	"poker.dealt.river":   {Other: "Se reparte el river.\n"},
+	"poker.rake":          {Other: "La casa se queda una comisión de %d.\n"},
+	"poker.shows":         {Other: "%s muestra %s: %s\n"},
+	"poker.pot":           {Other: "el bote"},
+	"poker.main_pot":      {Other: "el bote principal"},
+	"poker.side_pot":      {Other: "el bote secundario %d"},
+	"poker.and":           {Other: " y "},
+	"poker.wins":          {Other: "¡%s gana %s de %d!\n"},
+	"poker.title":         {Other: "**Texas Hold'em** (ciegas %d/%d, entrada %d)\n\n"},
+	"poker.no_board":      {Other: "ninguna todavía"},
+	"poker.board":         {Other: "Mesa: %s\nBote: %d\n\n"},
+	"poker.seat":          {Other: "%s: %d fichas"},
+	"poker.button":        {Other: " (botón)"},
+	"poker.sitting_out":   {Other: ", fuera de la mano"},
+	"poker.folded":        {Other: ", retirado"},
+	"poker.all_in_for":    {Other: ", con todo por %d"},
+	"poker.bet":           {Other: ", apuesta %d"},
+	"poker.turn":          {Other: "\nEs el turno de <@%s>."},
+	"poker.between_hands": {Other: "\nPulsa Unirse para sentarte y Repartir para empezar la siguiente mano."},
+	"poker.check_button":  {Other: "Pasar"},
+	"poker.call_button":   {Other: "Igualar %d"},
+	"poker.fold_button":   {Other: "Retirarse"},
+	"poker.raise_button":  {Other: "Subir"},
+	"poker.all_in_button": {Other: "Con todo"},
+	"poker.cards_button":  {Other: "Mis cartas"},
+	"poker.join_button":   {Other: "Unirse"},
+	"poker.leave_button":  {Other: "Salir"},
+	"poker.deal_button":   {Other: "Repartir"},
+	"poker.idle":          {Other: "%s tardó demasiado en actuar. "},
+
+	"poker.min_buy_in":        {Other: "La entrada debería ser de al menos 10 ciegas grandes, es decir, %d."},
+	"poker.not_enough_chips":  {Other: "¡No tienes suficientes fichas para entrar! Tu saldo actual es: %d"},
+	"poker.cant_open":         {Other: "No puedes abrir una mesa: %s."},
+	"poker.sits":              {Other: "%s se sienta con %d fichas."},
+	"poker.opening":           {Other: "¡%s abre una mesa de Texas Hold'em! La entrada es de %d y las ciegas son %d/%d."},
+	"poker.thread":            {Other: "Hold'em con %s"},
+	"poker.cant_join":         {Other: "No puedes unirte: %s."},
+	"poker.cant_leave":        {Other: "No puedes irte: %s."},
+	"poker.leaves":            {Other: "%s deja la mesa con %d fichas."},
+	"poker.closed":            {Other: "Se han ido todos, así que la mesa está cerrada."},
+	"poker.only_players_deal": {Other: "Solo los jugadores sentados a la mesa pueden repartir."},
+	"poker.cant_deal":         {Other: "Todavía no puedes repartir: %s."},
+	"poker.no_cards":          {Other: "No tienes cartas en esta mano."},
+	"poker.your_cards":        {Other: "Tus cartas son: %s"},
+	"poker.best_hand":         {Other: "\nTu mejor mano es: %s"},
+	"poker.not_your_turn":     {Other: "No es tu turno."},
+	"poker.raise_placeholder": {Other: "Al menos %d, como mucho %d"},
+	"poker.raise_to":          {Other: "Subir a"},
+	"poker.table_closed":      {Other: "Esta mesa está cerrada."},
+	"poker.raise_number":      {Other: "La cantidad a la que subir tiene que ser un número entero."},
+	"poker.cant":              {Other: "No puedes hacer eso: %s."},
+
+	"card.ace":   {One: "As", Other: "Ases"},
+	"card.two":   {One: "Dos", Other: "Doses"},
+	"card.three": {One: "Tres", Other: "Treses"},
+	"card.four":  {One: "Cuatro", Other: "Cuatros"},
+	"card.five":  {One: "Cinco", Other: "Cincos"},
+	"card.six":   {One: "Seis", Other: "Seises"},
+	"card.seven": {One: "Siete", Other: "Sietes"},
+	"card.eight": {One: "Ocho", Other: "Ochos"},
+	"card.nine":  {One: "Nueve", Other: "Nueves"},
+	"card.ten":   {One: "Diez", Other: "Dieces"},
+	"card.jack":  {One: "Jota", Other: "Jotas"},
+	"card.queen": {One: "Reina", Other: "Reinas"},
+	"card.king":  {One: "Rey", Other: "Reyes"},
+
+	"strategy.hit":                  {Other: "Pedir"},
+	"strategy.stand":                {Other: "Plantarse"},
+	"strategy.double":               {Other: "Doblar"},
+	"strategy.split":                {Other: "Separar"},
+	"strategy.unknown":              {Other: "Desconocida"},
+	"strategy.hand.hard":            {Other: "un %d duro"},
+	"strategy.hand.soft":            {Other: "un %d blando"},
+	"strategy.hand.pairs":           {Other: "una pareja de %s"},
+	"strategy.advice":               {Other: "Con %s contra la carta visible del crupier (%s), deberías **%s**.\n\n"},
+	"strategy.ev_header":            {Other: "Fichas ganadas esperadas por ficha apostada:\n"},
+	"strategy.not_available":        {Other: " (no disponible)"},
+	"strategy.even_better":          {Other: "\n\n%s sería aún mejor, pero no está disponible en esta partida."},
+	"strategy.reason.strong":        {Other: "Tu mano ya es fuerte, y es demasiado probable que otra carta te haga pasarte."},
+	"strategy.reason.weak_upcard":   {Other: "La carta visible del crupier es débil, así que deja que sea él quien se arriesgue a pasarse."},
+	"strategy.reason.bust_risk":     {Other: "Pedir otra carta te hace pasarte demasiado a menudo para que valga la pena, aunque tu mano sea débil."},
+	"strategy.reason.soft":          {Other: "Tu as puede volver a contar como 1, así que otra carta no puede hacerte pasarte y solo puede mejorar la mano."},
+	"strategy.reason.cant_bust":     {Other: "Ninguna carta puede hacerte pasarte, así que no pierdes nada pidiendo otra."},
+	"strategy.reason.strong_upcard": {Other: "Es probable que el crupier acabe con una mano fuerte, así que plantarse aquí pierde más a menudo que arriesgarse a pasarse."},
+	"strategy.reason.double":        {Other: "Es probable que tu mano mejore con una carta más, así que vale la pena poner más fichas en ella."},
+	"strategy.reason.split":         {Other: "Dos manos que empiezan con una de estas cartas ganan más cada una que la pareja junta."},
+
+	"trainer.decision_correct": {Other: "¡Correcto! %s es la jugada adecuada aquí.\n\n"},
+	"trainer.decision_mistake": {Other: "¡Error! La estrategia básica dice **%s** aquí, no %s.\n%s\n\n"},
+
+	"blackjack.hand_value": {Other: "\n\nEl valor es: %d"},
+
+	"roulette_table.type.red":          {Other: "Rojo"},
+	"roulette_table.type.black":        {Other: "Negro"},
+	"roulette_table.type.odd":          {Other: "Impar"},
+	"roulette_table.type.even":         {Other: "Par"},
+	"roulette_table.type.low":          {Other: "Falta (1-18)"},
+	"roulette_table.type.high":         {Other: "Pasa (19-36)"},
+	"roulette_table.type.dozen_1":      {Other: "1.ª docena"},
+	"roulette_table.type.dozen_2":      {Other: "2.ª docena"},
+	"roulette_table.type.dozen_3":      {Other: "3.ª docena"},
+	"roulette_table.type.column_1":     {Other: "1.ª columna"},
+	"roulette_table.type.column_2":     {Other: "2.ª columna"},
+	"roulette_table.type.column_3":     {Other: "3.ª columna"},
+	"roulette_table.modal_placeholder": {Other: "Por ejemplo: straight 17 5, split 17-20 5, corner 1-2-4-5 5..."},
+}
@@ -8,6 +8,69 @@ import (
 type Configuration struct {
 	Token  string
 	DbPath string
+
+	// Defaults for the card counting trainer
+	CountingDecks         int
+	CountingDealSeconds   float64
+	CountingCardsPerCheck int
+
+	// SlotsPath is the file the slot machine's reels, paylines and paytable are read from
+	SlotsPath string
+
+	// BlackjackDecks is the number of decks in the blackjack shoe. The suited sevens jackpot needs more than one.
+	BlackjackDecks int
+
+	// JackpotPercent is the percentage of every wager added to the jackpot pool
+	JackpotPercent float64
+	// JackpotSeed is the number of chips the jackpot starts at, and resets to when it is won
+	JackpotSeed int
+	// JackpotChannelID is the channel jackpot wins are announced in. Left empty, they aren't announced.
+	JackpotChannelID string
+	// PokerRakePercent is the percentage of each poker pot the house takes. Left at 0, there is no rake.
+	PokerRakePercent float64
+	// PokerRakeCap is the most chips the house takes from a single pot. Left at 0, the rake isn't capped.
+	PokerRakeCap int
+	// BaccaratDecks is the number of decks in each channel's baccarat shoe
+	BaccaratDecks int
+	// LotteryTicketPrice is the number of chips a lottery ticket costs
+	LotteryTicketPrice int
+	// LotteryDrawHour is the hour of the day, in UTC, the lottery is drawn
+	LotteryDrawHour int
+	// LotteryChannelID is the channel lottery draws are announced in. Left empty, they aren't announced.
+	LotteryChannelID string
+	// CardStyle is how cards are shown: "emoji" for the rank and a suit emoji, "unicode" for playing card characters,
+	// or "name" for the full name, e.g. "Queen of Hearts". Left empty, cards are shown as emoji.
+	CardStyle string
+	// TextHands shows blackjack hands as text, instead of attaching an image of the table to each message
+	TextHands bool
+	// DailyReward is the number of chips /daily gives, before any streak bonus
+	DailyReward int
+	// DailyStreakBonus is the extra chips /daily gives for each day of a streak after the first
+	DailyStreakBonus int
+	// DailyStreakMaxDays is the most days of a streak that add the bonus
+	DailyStreakMaxDays int
+	// HourlyReward is the number of chips /hourly gives
+	HourlyReward int
+	// TransferTaxPercent is the percentage of each /give transfer the house takes. Left at 0, there is no tax.
+	TransferTaxPercent float64
+	// TransferDailyLimit is the most chips a member can give away in a day. Left at 0, there is no limit.
+	TransferDailyLimit int
+	// TransferMinAccountDays is how many days old a Discord account has to be to give chips away. Left at 0, any
+	// account can.
+	TransferMinAccountDays int
+	// LoanBaseLimit is the most chips a new player can borrow. Each loan repaid adds this to the limit, and each loan
+	// defaulted on takes it off.
+	LoanBaseLimit int
+	// LoanLimitPerGame is how much the credit limit goes up for every game played
+	LoanLimitPerGame int
+	// LoanMaxLimit is the most chips anyone can borrow
+	LoanMaxLimit int
+	// LoanInterestPercent is the interest added to loans each day, compounded
+	LoanInterestPercent float64
+	// LoanTermDays is how many days a loan has to be repaid in before it's in default
+	LoanTermDays int
+	// LoanRepayPercent is the percentage of each blackjack win that goes to paying off a loan
+	LoanRepayPercent int
 }
 
 func GetConfig() Configuration {
@@ -19,5 +82,70 @@ func GetConfig() Configuration {
 		log.Fatal(err)
 	}
 
+	// Using defaults for any settings left out of the file
+	if config.CountingDecks <= 0 {
+		config.CountingDecks = 6
+	}
+	if config.CountingDealSeconds <= 0 {
+		config.CountingDealSeconds = 2
+	}
+	if config.CountingCardsPerCheck <= 0 {
+		config.CountingCardsPerCheck = 10
+	}
+	if config.SlotsPath == "" {
+		config.SlotsPath = "slots.json"
+	}
+	if config.BlackjackDecks <= 0 {
+		config.BlackjackDecks = 6
+	}
+	if config.JackpotPercent <= 0 {
+		config.JackpotPercent = 1
+	}
+	if config.JackpotSeed <= 0 {
+		config.JackpotSeed = 100
+	}
+	if config.BaccaratDecks <= 0 {
+		config.BaccaratDecks = 8
+	}
+	if config.LotteryTicketPrice <= 0 {
+		config.LotteryTicketPrice = 10
+	}
+	if config.LotteryDrawHour < 0 || config.LotteryDrawHour > 23 {
+		config.LotteryDrawHour = 0
+	}
+	if config.CardStyle == "" {
+		config.CardStyle = CardStyleEmoji
+	}
+	if config.DailyReward <= 0 {
+		config.DailyReward = 25
+	}
+	if config.DailyStreakBonus <= 0 {
+		config.DailyStreakBonus = 5
+	}
+	if config.DailyStreakMaxDays <= 0 {
+		config.DailyStreakMaxDays = 7
+	}
+	if config.HourlyReward <= 0 {
+		config.HourlyReward = 5
+	}
+	if config.LoanBaseLimit <= 0 {
+		config.LoanBaseLimit = 100
+	}
+	if config.LoanLimitPerGame <= 0 {
+		config.LoanLimitPerGame = 2
+	}
+	if config.LoanMaxLimit <= 0 {
+		config.LoanMaxLimit = 2000
+	}
+	if config.LoanInterestPercent <= 0 {
+		config.LoanInterestPercent = 2
+	}
+	if config.LoanTermDays <= 0 {
+		config.LoanTermDays = 7
+	}
+	if config.LoanRepayPercent <= 0 || config.LoanRepayPercent > 100 {
+		config.LoanRepayPercent = 50
+	}
+
 	return config
 }
@@ -0,0 +1,473 @@
+// This file handles the card counting trainer. Cards from a multi-deck shoe are dealt into a thread, and every so often
+// the player is asked for the Hi-Lo running count and true count.
+package main
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Penetration is how far into the shoe the cards are dealt before it is reshuffled
+const Penetration = 0.75
+
+// HiLoValue Returns the Hi-Lo count value of a card. Two to Six count +1, Seven to Nine count 0, and tens and aces count -1.
+func HiLoValue(card Card) int {
+	value := card.Rank.Value()
+	switch {
+	case value >= 2 && value <= 6:
+		return 1
+	case value >= 7 && value <= 9:
+		return 0
+	}
+	return -1
+}
+
+// CountingStats The results of card counting trainer sessions
+type CountingStats struct {
+	Sessions       int
+	Checks         int
+	RunningCorrect int
+	TrueCorrect    int
+	ResponseTime   time.Duration
+}
+
+// AverageResponse Returns the average time taken to answer a count check
+func (c CountingStats) AverageResponse() time.Duration {
+	if c.Checks == 0 {
+		return 0
+	}
+	return c.ResponseTime / time.Duration(c.Checks)
+}
+
+// CountingSession A card counting trainer session for one player
+type CountingSession struct {
+	mu sync.Mutex
+
+	Player        Player
+	ChannelID     string
+	Decks         int
+	Shoe          Deck
+	RunningCount  int
+	Interval      time.Duration
+	CardsPerCheck int
+
+	// cardsSinceCheck is how many cards have been dealt since the player was last asked for the count
+	cardsSinceCheck int
+	// AwaitingAnswer is true while dealing is paused for the player to enter the count
+	AwaitingAnswer bool
+	PromptedAt     time.Time
+
+	Stats CountingStats
+
+	// Locale is the language the session is shown in
+	Locale string
+
+	stop    chan struct{}
+	stopped bool
+}
+
+var (
+	// CountingSessionsMap ongoing card counting trainer sessions, by username
+	CountingSessionsMap = make(map[string]*CountingSession)
+	countingSessionsMu  sync.Mutex
+)
+
+// NewCountingSession Creates a new counting trainer session with a freshly shuffled shoe
+func NewCountingSession(player Player, channelID string, decks int, interval time.Duration, cardsPerCheck int) *CountingSession {
+	return &CountingSession{
+		Player:        player,
+		ChannelID:     channelID,
+		Decks:         decks,
+		Shoe:          NewShoe(decks),
+		Interval:      interval,
+		CardsPerCheck: cardsPerCheck,
+		Locale:        DefaultLocale,
+		stop:          make(chan struct{}),
+	}
+}
+
+// DecksRemaining Returns the number of decks left in the shoe, used to work out the true count
+func (c *CountingSession) DecksRemaining() float64 {
+	return float64(len(c.Shoe)) / 52
+}
+
+// TrueCount Returns the running count divided by the number of decks remaining
+func (c *CountingSession) TrueCount() float64 {
+	return float64(c.RunningCount) / c.DecksRemaining()
+}
+
+// Deal Deals cards into the session's channel until it is time to check the player's count, or the session is stopped.
+// Meant to be run as a goroutine.
+func (c *CountingSession) Deal() {
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+
+		c.mu.Lock()
+
+		// Reshuffling once the shoe is dealt down to the penetration, which resets the count
+		if float64(len(c.Shoe)) <= float64(c.Decks*52)*(1-Penetration) {
+			c.Shoe = NewShoe(c.Decks)
+			c.RunningCount = 0
+			c.cardsSinceCheck = 0
+			_, _ = s.ChannelMessageSend(c.ChannelID, T(c.Locale, "counting.reshuffled"))
+		}
+
+		card := c.Shoe.DealCard()
+		c.RunningCount += HiLoValue(card)
+		c.cardsSinceCheck++
+
+		check := c.cardsSinceCheck >= c.CardsPerCheck
+
+		c.mu.Unlock()
+
+		_, _ = s.ChannelMessageSend(c.ChannelID, card.String())
+
+		if check {
+			DisplayCountButtons(c.ChannelID, c.Locale)
+
+			// The answer time starts once the player has been asked
+			c.mu.Lock()
+			c.cardsSinceCheck = 0
+			c.AwaitingAnswer = true
+			c.PromptedAt = time.Now()
+			c.mu.Unlock()
+			return
+		}
+	}
+
+}
+
+// IsAwaitingAnswer Returns whether dealing is paused for the player to enter the count
+func (c *CountingSession) IsAwaitingAnswer() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.AwaitingAnswer
+}
+
+// CheckAnswer Scores the player's answer for the running count and true count, then returns a message with the results.
+// The true count is accepted if it is the exact true count rounded or truncated to a whole number.
+// Returns false if the player wasn't being asked for the count, so an answer can't be scored twice.
+func (c *CountingSession) CheckAnswer(runningCount int, trueCount int) (string, bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.AwaitingAnswer {
+		return "", false
+	}
+
+	responseTime := time.Since(c.PromptedAt)
+	exactTrueCount := c.TrueCount()
+
+	runningCorrect := runningCount == c.RunningCount
+	trueCorrect := float64(trueCount) == math.Round(exactTrueCount) || float64(trueCount) == math.Trunc(exactTrueCount)
+
+	c.AwaitingAnswer = false
+	c.Stats.Checks++
+	c.Stats.ResponseTime += responseTime
+	if runningCorrect {
+		c.Stats.RunningCorrect++
+	}
+	if trueCorrect {
+		c.Stats.TrueCorrect++
+	}
+
+	var sb strings.Builder
+	sb.WriteString(T(c.Locale, "counting.running_answer", runningCount, c.RunningCount, correctText(c.Locale, runningCorrect)))
+	sb.WriteString(T(c.Locale, "counting.true_answer", trueCount, exactTrueCount, c.DecksRemaining(), correctText(c.Locale, trueCorrect)))
+	sb.WriteString(T(c.Locale, "counting.answer_time", responseTime.Seconds()))
+
+	return sb.String(), true
+
+}
+
+// correctText Returns the text shown after an answer in the counting trainer
+func correctText(locale string, correct bool) string {
+	if correct {
+		return T(locale, "counting.correct")
+	}
+	return T(locale, "counting.wrong")
+}
+
+// Stop Stops dealing cards. Safe to call more than once.
+func (c *CountingSession) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.stopped {
+		close(c.stop)
+		c.stopped = true
+	}
+}
+
+// Summary Returns a message with the results of the session
+func (c *CountingSession) Summary() string {
+
+	if c.Stats.Checks == 0 {
+		return T(c.Locale, "counting.over_empty")
+	}
+
+	return T(c.Locale, "counting.over",
+		c.Stats.RunningCorrect, c.Stats.Checks,
+		c.Stats.TrueCorrect, c.Stats.Checks,
+		c.Stats.AverageResponse().Seconds(),
+	)
+
+}
+
+// GetCountingStats Returns a message in the given language with the player's card counting trainer totals
+func GetCountingStats(locale string, player Player) string {
+
+	stats, ok := dba.GetCountingStats(player)
+	if !ok || stats.Checks == 0 {
+		return T(locale, "counting.no_stats")
+	}
+
+	return TN(locale, "counting.stats", stats.Sessions,
+		stats.Sessions,
+		float64(stats.RunningCorrect)/float64(stats.Checks)*100,
+		float64(stats.TrueCorrect)/float64(stats.Checks)*100,
+		stats.AverageResponse().Seconds(),
+	)
+
+}
+
+// FindCountingSession is a helper function to find a counting session by the channel it is in
+func FindCountingSession(channelID string) *CountingSession {
+
+	countingSessionsMu.Lock()
+	defer countingSessionsMu.Unlock()
+
+	for _, session := range CountingSessionsMap {
+		if session.ChannelID == channelID {
+			return session
+		}
+	}
+
+	return nil
+
+}
+
+// DisplayCountButtons sends the message asking the player for the count, with buttons to answer or stop the session
+func DisplayCountButtons(channelID string, locale string) {
+
+	_, _ = s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Content: T(locale, "counting.prompt"),
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    T(locale, "counting.enter_button"),
+						Style:    discordgo.SuccessButton,
+						CustomID: "count-answer",
+					},
+					discordgo.Button{
+						Label:    T(locale, "counting.stop_button"),
+						Style:    discordgo.DangerButton,
+						CustomID: "count-stop",
+					},
+				},
+			},
+		},
+	})
+
+}
+
+// CountTrainerCommand handles the /count-trainer command, starting a new counting session in a thread
+func CountTrainerCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	// Getting options and storing in map
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	locale := LocaleFor(i)
+	player := dba.FindPlayer(i.Member.User.Username)
+
+	countingSessionsMu.Lock()
+	_, ok := CountingSessionsMap[player.Username]
+	countingSessionsMu.Unlock()
+	if ok {
+		RespondEphemeral(i, T(locale, "counting.in_session"))
+		return
+	}
+
+	// Using the configured defaults for anything the player left out
+	decks := Config.CountingDecks
+	if opt, ok := optionMap["decks"]; ok {
+		decks = int(opt.IntValue())
+	}
+	seconds := Config.CountingDealSeconds
+	if opt, ok := optionMap["seconds"]; ok {
+		seconds = opt.FloatValue()
+	}
+	cardsPerCheck := Config.CountingCardsPerCheck
+	if opt, ok := optionMap["cards"]; ok {
+		cardsPerCheck = int(opt.IntValue())
+	}
+
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: T(locale, "counting.starting", player.Username, decks, seconds, cardsPerCheck),
+		},
+	})
+
+	channelID := StartGameThread(i, T(locale, "counting.thread", player.Username))
+	session := NewCountingSession(player, channelID, decks, time.Duration(seconds*float64(time.Second)), cardsPerCheck)
+	session.Locale = locale
+
+	countingSessionsMu.Lock()
+	CountingSessionsMap[player.Username] = session
+	countingSessionsMu.Unlock()
+
+	go session.Deal()
+
+}
+
+// CountAnswerButton handles the button to enter the count, showing the player a form to enter it in
+func CountAnswerButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	session := FindCountingSession(i.ChannelID)
+
+	// Only the player in the session can answer, and only when they're being asked
+	if session == nil || session.Player.Username != i.Member.User.Username || !session.IsAwaitingAnswer() {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: "count-modal",
+			Title:    T(session.Locale, "counting.prompt"),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:  "running",
+							Label:     T(session.Locale, "counting.running_label"),
+							Style:     discordgo.TextInputShort,
+							Required:  true,
+							MaxLength: 4,
+						},
+					},
+				},
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:  "true",
+							Label:     T(session.Locale, "counting.true_label"),
+							Style:     discordgo.TextInputShort,
+							Required:  true,
+							MaxLength: 4,
+						},
+					},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// CountModalSubmit handles the player's answer to a count check, then starts dealing again
+func CountModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	session := FindCountingSession(i.ChannelID)
+	if session == nil || session.Player.Username != i.Member.User.Username {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	// Reading the answers out of the form
+	answers := make(map[string]string)
+	for _, row := range i.ModalSubmitData().Components {
+		for _, component := range row.(*discordgo.ActionsRow).Components {
+			input := component.(*discordgo.TextInput)
+			answers[input.CustomID] = strings.TrimSpace(input.Value)
+		}
+	}
+
+	runningCount, err := strconv.Atoi(answers["running"])
+	if err != nil {
+		RespondEphemeral(i, T(session.Locale, "counting.running_number"))
+		return
+	}
+	trueCount, err := strconv.Atoi(answers["true"])
+	if err != nil {
+		RespondEphemeral(i, T(session.Locale, "counting.true_number"))
+		return
+	}
+
+	results, ok := session.CheckAnswer(runningCount, trueCount)
+	if !ok {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: results,
+		},
+	})
+
+	go session.Deal()
+
+}
+
+// CountStopButton handles the button to stop a counting session, saving the results to the database
+func CountStopButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	session := FindCountingSession(i.ChannelID)
+
+	// if the session is nil we need to remove the buttons because the session is over now
+	if session == nil {
+		RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+		return
+	}
+
+	if session.Player.Username != i.Member.User.Username {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+
+	session.Stop()
+
+	countingSessionsMu.Lock()
+	delete(CountingSessionsMap, session.Player.Username)
+	countingSessionsMu.Unlock()
+
+	if session.Stats.Checks > 0 {
+		dba.RecordCountingSession(session.Player, session.Stats)
+	}
+
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: session.Summary(),
+		},
+	})
+
+}
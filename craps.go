@@ -0,0 +1,535 @@
+// This file implements craps. Each channel has a table that players bet on while a shooter rolls the dice. The table
+// moves between the come out roll and a point being set, and its state and bets are kept in the database, so a game
+// carries on between shooters and restarts.
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rodaine/table"
+)
+
+// Craps bet types
+const (
+	CrapsPass     = "pass"
+	CrapsDontPass = "dontpass"
+	CrapsCome     = "come"
+	CrapsDontCome = "dontcome"
+	CrapsOdds     = "odds"
+	CrapsPlace    = "place"
+	CrapsField    = "field"
+)
+
+// CrapsMaxOdds is the most that can be bet as odds, as a multiple of the line bet they back
+const CrapsMaxOdds = 3
+
+// CrapsPoints are the numbers that can be a point, and that place bets can be made on
+var CrapsPoints = []int{4, 5, 6, 8, 9, 10}
+
+// crapsNumberChoices Returns the numbers that can be bet on, as choices for the /craps command
+func crapsNumberChoices() []*discordgo.ApplicationCommandOptionChoice {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, number := range CrapsPoints {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: fmt.Sprint(number), Value: number})
+	}
+	return choices
+}
+
+// crapsMu is held while a craps table is loaded, changed and saved, so two rolls or bets can't interleave
+var crapsMu sync.Mutex
+
+// CrapsBet A bet on a craps table. The chips bet are taken from the player when it is placed.
+type CrapsBet struct {
+	Username string
+	Type     string
+	// Number is the number a place bet is on, or the point a come or don't come bet has moved to.
+	// It is 0 for a come bet that hasn't moved yet.
+	Number int
+	Amount int
+	// Odds is the chips backing a line or come bet once it has a point, paid at true odds
+	Odds int
+}
+
+// Implementing the stringer interface for CrapsBet
+func (b CrapsBet) String() string {
+	return b.StringIn(DefaultLocale)
+}
+
+// StringIn Returns the name of the bet in the given language, with its number if it has one
+func (b CrapsBet) StringIn(locale string) string {
+	name := T(locale, "craps.bet."+b.Type)
+	if b.Number != 0 {
+		name += fmt.Sprintf(" %d", b.Number)
+	}
+	return name
+}
+
+// contract Returns whether the bet is a line or come bet, which stay up until they win or lose
+func (b CrapsBet) contract() bool {
+	return b.Type == CrapsPass || b.Type == CrapsDontPass || b.Type == CrapsCome || b.Type == CrapsDontCome
+}
+
+// darkSide Returns whether the bet is betting against the shooter
+func (b CrapsBet) darkSide() bool {
+	return b.Type == CrapsDontPass || b.Type == CrapsDontCome
+}
+
+// CrapsTable The craps table in a channel
+type CrapsTable struct {
+	ChannelID string
+	// Point is the point the shooter is trying to make, or 0 if the next roll is a come out roll
+	Point int
+	// Shooter is the player rolling the dice. Left empty, anyone can pick them up.
+	Shooter string
+	// Shooters are the players who have bet at the table, in the order they joined, which the dice pass around
+	Shooters []string
+	Bets     []CrapsBet
+}
+
+// HasBets Returns whether the player has any bets on the table
+func (t *CrapsTable) HasBets(username string) bool {
+	return slices.ContainsFunc(t.Bets, func(b CrapsBet) bool { return b.Username == username })
+}
+
+// PlaceBet Adds a bet to the table, checking it can be made right now. Bets of the same type and number are added
+// together. Odds are added to the player's line bet, or their come bet on the number if one is given.
+func (t *CrapsTable) PlaceBet(bet CrapsBet) error {
+
+	switch bet.Type {
+	case CrapsPass, CrapsDontPass:
+		if t.Point != 0 {
+			return NewLocalizedError("craps.error.line_bet")
+		}
+		bet.Number = 0
+	case CrapsCome, CrapsDontCome:
+		if t.Point == 0 {
+			return NewLocalizedError("craps.error.come_bet")
+		}
+		bet.Number = 0
+	case CrapsPlace:
+		if !slices.Contains(CrapsPoints, bet.Number) {
+			return NewLocalizedError("craps.error.place_bet")
+		}
+	case CrapsField:
+		bet.Number = 0
+	case CrapsOdds:
+		return t.placeOdds(bet)
+	default:
+		return NewLocalizedError("craps.error.type", bet.Type)
+	}
+
+	for i, existing := range t.Bets {
+		if existing.Username == bet.Username && existing.Type == bet.Type && existing.Number == bet.Number {
+			t.Bets[i].Amount += bet.Amount
+			return nil
+		}
+	}
+
+	t.Bets = append(t.Bets, bet)
+	if !slices.Contains(t.Shooters, bet.Username) {
+		t.Shooters = append(t.Shooters, bet.Username)
+	}
+
+	return nil
+
+}
+
+// placeOdds Adds odds to the line or come bet they back, up to CrapsMaxOdds times the bet
+func (t *CrapsTable) placeOdds(bet CrapsBet) error {
+
+	for i, existing := range t.Bets {
+		if existing.Username != bet.Username || !existing.contract() {
+			continue
+		}
+
+		// Without a number, odds back the line bet. With one, they back the come bet that moved to it.
+		line := existing.Type == CrapsPass || existing.Type == CrapsDontPass
+		if (bet.Number == 0) != line || (!line && existing.Number != bet.Number) {
+			continue
+		}
+		if line && t.Point == 0 {
+			return NewLocalizedError("craps.error.line_odds")
+		}
+		if existing.Odds+bet.Amount > existing.Amount*CrapsMaxOdds {
+			return NewLocalizedError("craps.error.max_odds", CrapsMaxOdds, existing.Amount*CrapsMaxOdds-existing.Odds)
+		}
+
+		t.Bets[i].Odds += bet.Amount
+		return nil
+	}
+
+	if bet.Number == 0 {
+		return NewLocalizedError("craps.error.no_line_bet")
+	}
+	return NewLocalizedError("craps.error.no_come_bet", bet.Number)
+
+}
+
+// CrapsOutcome What happened to a bet after a roll. Returned is the chips given back to the player, including the
+// bet itself if it came down, and Net is the chips won or lost.
+type CrapsOutcome struct {
+	Bet      CrapsBet
+	Returned int
+	Net      int
+}
+
+// OddsPays Returns the chips won by odds on the point, at true odds. Laying odds against the point pays the inverse.
+// Any fraction of a chip is rounded down.
+func OddsPays(point int, amount int, lay bool) int {
+
+	// The number of ways to roll a 7 against the number of ways to roll the point
+	sevens, points := 6, map[int]int{4: 3, 5: 4, 6: 5, 8: 5, 9: 4, 10: 3}[point]
+	if lay {
+		return amount * points / sevens
+	}
+
+	return amount * sevens / points
+
+}
+
+// PlacePays Returns the chips won by a place bet on the number. Place bets pay a little under true odds.
+func PlacePays(number int, amount int) int {
+	switch number {
+	case 4, 10:
+		return amount * 9 / 5
+	case 5, 9:
+		return amount * 7 / 5
+	default:
+		return amount * 7 / 6
+	}
+}
+
+// FieldPays Returns the chips won by a field bet on the total, or 0 if it loses. 2 pays double and 12 pays triple.
+func FieldPays(total int, amount int) int {
+	switch total {
+	case 2:
+		return amount * 2
+	case 12:
+		return amount * 3
+	case 3, 4, 9, 10, 11:
+		return amount
+	default:
+		return 0
+	}
+}
+
+// Roll Settles every bet on the table for the total of the dice, and moves the table on to its next state.
+// Returns what happened to each bet that won or lost, and whether the shooter sevened out.
+func (t *CrapsTable) Roll(total int) ([]CrapsOutcome, bool) {
+
+	var outcomes []CrapsOutcome
+	var remaining []CrapsBet
+
+	win := func(bet CrapsBet, net int) {
+		outcomes = append(outcomes, CrapsOutcome{Bet: bet, Returned: bet.Amount + bet.Odds + net, Net: net})
+	}
+	lose := func(bet CrapsBet) {
+		outcomes = append(outcomes, CrapsOutcome{Bet: bet, Net: -bet.Amount - bet.Odds})
+	}
+
+	for _, bet := range t.Bets {
+
+		// Line bets use the table's point, come bets their own. Either way 0 means their next roll is a come out roll.
+		point := bet.Number
+		if bet.Type == CrapsPass || bet.Type == CrapsDontPass {
+			point = t.Point
+		}
+
+		switch {
+		case bet.Type == CrapsField:
+			if pays := FieldPays(total, bet.Amount); pays > 0 {
+				win(bet, pays)
+			} else {
+				lose(bet)
+			}
+
+		case bet.Type == CrapsPlace:
+			// Place bets are off on the come out roll, and stay up after they win
+			switch {
+			case t.Point == 0:
+				remaining = append(remaining, bet)
+			case total == bet.Number:
+				outcomes = append(outcomes, CrapsOutcome{Bet: bet, Returned: PlacePays(bet.Number, bet.Amount), Net: PlacePays(bet.Number, bet.Amount)})
+				remaining = append(remaining, bet)
+			case total == 7:
+				lose(bet)
+			default:
+				remaining = append(remaining, bet)
+			}
+
+		case point == 0 && !bet.darkSide():
+			switch total {
+			case 7, 11:
+				win(bet, bet.Amount)
+			case 2, 3, 12:
+				lose(bet)
+			default:
+				if bet.Type == CrapsCome {
+					bet.Number = total
+				}
+				remaining = append(remaining, bet)
+			}
+
+		case point == 0:
+			switch total {
+			case 2, 3:
+				win(bet, bet.Amount)
+			case 7, 11:
+				lose(bet)
+			case 12:
+				// Barred, so the bet is a push and stays up
+				remaining = append(remaining, bet)
+			default:
+				if bet.Type == CrapsDontCome {
+					bet.Number = total
+				}
+				remaining = append(remaining, bet)
+			}
+
+		case (total == point || total == 7) && t.Point == 0 && bet.Odds > 0:
+			// Odds on come bets are off on the come out roll, so they are returned whatever happens to the bet
+			net := -bet.Amount
+			if (total == point) != bet.darkSide() {
+				net = bet.Amount
+			}
+			outcomes = append(outcomes, CrapsOutcome{Bet: bet, Returned: bet.Amount + bet.Odds + net, Net: net})
+
+		case total == point && !bet.darkSide(), total == 7 && bet.darkSide():
+			win(bet, bet.Amount+OddsPays(point, bet.Odds, bet.darkSide()))
+
+		case total == point, total == 7:
+			lose(bet)
+
+		default:
+			remaining = append(remaining, bet)
+		}
+
+	}
+
+	t.Bets = remaining
+
+	// Moving the table on. Making the point or a come out 7 keeps the dice with the shooter, but a 7 after a point
+	// is set passes them on.
+	sevenOut := false
+	switch {
+	case t.Point == 0 && slices.Contains(CrapsPoints, total):
+		t.Point = total
+	case t.Point != 0 && total == t.Point:
+		t.Point = 0
+	case t.Point != 0 && total == 7:
+		t.Point = 0
+		sevenOut = true
+		t.passDice()
+	}
+
+	return outcomes, sevenOut
+
+}
+
+// passDice Gives the dice to the next player in the rotation who still has bets on the table.
+// Players with no bets left leave the rotation, and if nobody is left the dice are free for anyone to pick up.
+func (t *CrapsTable) passDice() {
+
+	previous := slices.Index(t.Shooters, t.Shooter)
+
+	var shooters []string
+	for _, username := range t.Shooters {
+		if t.HasBets(username) {
+			shooters = append(shooters, username)
+		}
+	}
+
+	t.Shooter = ""
+	for offset := 1; offset <= len(t.Shooters); offset++ {
+		next := t.Shooters[(previous+offset+len(t.Shooters))%len(t.Shooters)]
+		if t.HasBets(next) {
+			t.Shooter = next
+			break
+		}
+	}
+
+	t.Shooters = shooters
+
+}
+
+// Content Returns a message in the given language showing the point, the shooter and every bet on the table
+func (t *CrapsTable) Content(locale string) string {
+
+	var sb strings.Builder
+
+	if t.Point == 0 {
+		sb.WriteString(T(locale, "craps.point_off"))
+	} else {
+		sb.WriteString(T(locale, "craps.point", t.Point))
+	}
+	if t.Shooter == "" {
+		sb.WriteString(T(locale, "craps.no_shooter"))
+	} else {
+		sb.WriteString(T(locale, "craps.shooter", t.Shooter))
+	}
+
+	if len(t.Bets) == 0 {
+		sb.WriteString(T(locale, "craps.no_bets"))
+		return sb.String()
+	}
+
+	tbl := table.New(T(locale, "craps.player"), T(locale, "craps.bet"), T(locale, "craps.amount"), T(locale, "craps.odds"))
+	tbl.WithWriter(&sb)
+	for _, bet := range t.Bets {
+		tbl.AddRow(bet.Username, bet.StringIn(locale), bet.Amount, bet.Odds)
+	}
+
+	sb.WriteString("```\n")
+	tbl.Print()
+	sb.WriteString("```")
+
+	return sb.String()
+
+}
+
+// CrapsCommand handles the /craps command and its subcommands for betting, rolling and seeing the table
+func CrapsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	locale := LocaleFor(i)
+
+	crapsMu.Lock()
+	defer crapsMu.Unlock()
+
+	craps := dba.GetCrapsTable(i.ChannelID)
+
+	var message string
+	switch subcommand.Name {
+	case "bet":
+		message = crapsBet(i, locale, &craps, subcommand.Options)
+	case "roll":
+		message = crapsRoll(i, locale, &craps)
+	default:
+		message = craps.Content(locale)
+	}
+
+	if message == "" {
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: message,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// crapsBet places a bet on the table, taking the chips from the player. Returns the message to respond with, or an
+// empty string if the interaction has already been responded to.
+func crapsBet(i *discordgo.InteractionCreate, locale string, craps *CrapsTable, options []*discordgo.ApplicationCommandInteractionDataOption) string {
+
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	bet := CrapsBet{
+		Username: i.Member.User.Username,
+		Type:     optionMap["type"].StringValue(),
+		Amount:   int(optionMap["amount"].IntValue()),
+	}
+	if opt, ok := optionMap["number"]; ok {
+		bet.Number = int(opt.IntValue())
+	}
+
+	player := dba.FindPlayer(bet.Username)
+	if player.Chips < bet.Amount {
+		RespondEphemeral(i, T(locale, "craps.not_enough_chips", player.Chips))
+		return ""
+	}
+
+	if err := craps.PlaceBet(bet); err != nil {
+		RespondEphemeral(i, T(locale, "craps.cant_bet", ErrorIn(locale, err)))
+		return ""
+	}
+
+	if err := dba.PlaceCrapsBet(&player, *craps, bet.Amount); err != nil {
+		RespondEphemeral(i, T(locale, "craps.not_enough_chips", dba.GetChipTotal(player.Username)))
+		return ""
+	}
+
+	name := bet.Type
+	if bet.Type != CrapsOdds {
+		name = bet.StringIn(locale)
+	}
+
+	return T(locale, "craps.bets", player.Username, bet.Amount, strings.ToLower(name), craps.Content(locale))
+
+}
+
+// crapsRoll rolls the dice for the shooter, settles the bets and pays the winners. Returns the message to respond
+// with, or an empty string if the interaction has already been responded to.
+func crapsRoll(i *discordgo.InteractionCreate, locale string, craps *CrapsTable) string {
+
+	username := i.Member.User.Username
+
+	// Picking up the dice if nobody has them, or the shooter has left the table
+	if craps.Shooter == "" || !craps.HasBets(craps.Shooter) {
+		if !craps.HasBets(username) {
+			RespondEphemeral(i, T(locale, "craps.need_bet"))
+			return ""
+		}
+		craps.Shooter = username
+	}
+	if craps.Shooter != username {
+		RespondEphemeral(i, T(locale, "craps.not_shooter", craps.Shooter))
+		return ""
+	}
+
+	first, second := RNG.Intn(6)+1, RNG.Intn(6)+1
+	total := first + second
+	point := craps.Point
+
+	outcomes, sevenOut := craps.Roll(total)
+
+	var sb strings.Builder
+	sb.WriteString(T(locale, "craps.rolls", username, first, second, total))
+	switch {
+	case sevenOut:
+		sb.WriteString(T(locale, "craps.seven_out"))
+		if craps.Shooter != "" {
+			sb.WriteString(T(locale, "craps.dice_pass", craps.Shooter))
+		}
+		sb.WriteString("\n")
+	case point == 0 && craps.Point != 0:
+		sb.WriteString(T(locale, "craps.point", craps.Point))
+	case point != 0 && craps.Point == 0:
+		sb.WriteString(T(locale, "craps.made_point"))
+	}
+
+	// Paying out the winnings and saving the table together
+	var results []string
+	for _, outcome := range outcomes {
+		if outcome.Net > 0 {
+			results = append(results, T(locale, "craps.wins", outcome.Bet.Username, outcome.Net, strings.ToLower(outcome.Bet.StringIn(locale))))
+		} else {
+			results = append(results, T(locale, "craps.loses", outcome.Bet.Username, -outcome.Net, strings.ToLower(outcome.Bet.StringIn(locale))))
+		}
+	}
+	for _, username := range dba.SettleCrapsRoll(*craps, outcomes) {
+		results = append(results, T(locale, "craps.pity", username, MinChips))
+	}
+
+	if len(results) > 0 {
+		sb.WriteString("\n" + strings.Join(results, "\n") + "\n")
+	}
+	sb.WriteString("\n" + craps.Content(locale))
+
+	return sb.String()
+
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCrapsRoll(t *testing.T) {
+
+	pass := CrapsBet{Username: "A", Type: CrapsPass, Amount: 10}
+	passWithOdds := CrapsBet{Username: "A", Type: CrapsPass, Amount: 10, Odds: 10}
+	dontPass := CrapsBet{Username: "A", Type: CrapsDontPass, Amount: 10}
+	place6 := CrapsBet{Username: "A", Type: CrapsPlace, Number: 6, Amount: 12}
+	come := CrapsBet{Username: "A", Type: CrapsCome, Amount: 10}
+	comeOn8 := CrapsBet{Username: "A", Type: CrapsCome, Number: 8, Amount: 10, Odds: 10}
+	field := CrapsBet{Username: "A", Type: CrapsField, Amount: 10}
+
+	tests := []struct {
+		name      string
+		point     int
+		bet       CrapsBet
+		total     int
+		want      []CrapsOutcome
+		remaining []CrapsBet
+		wantPoint int
+		sevenOut  bool
+	}{
+		{"pass wins on a come out 7", 0, pass, 7, []CrapsOutcome{{pass, 20, 10}}, nil, 0, false},
+		{"pass loses on a come out 2", 0, pass, 2, []CrapsOutcome{{pass, 0, -10}}, nil, 0, false},
+		{"come out 6 sets the point", 0, pass, 6, nil, []CrapsBet{pass}, 6, false},
+		{"don't pass wins on a come out 3", 0, dontPass, 3, []CrapsOutcome{{dontPass, 20, 10}}, nil, 0, false},
+		{"don't pass is barred on a come out 12", 0, dontPass, 12, nil, []CrapsBet{dontPass}, 0, false},
+		{"making the point pays the odds", 6, passWithOdds, 6, []CrapsOutcome{{passWithOdds, 42, 22}}, nil, 0, false},
+		{"seven out", 6, passWithOdds, 7, []CrapsOutcome{{passWithOdds, 0, -20}}, nil, 0, true},
+		{"don't pass wins on a seven out", 6, dontPass, 7, []CrapsOutcome{{dontPass, 20, 10}}, nil, 0, true},
+		{"place bets are off on the come out", 0, place6, 7, nil, []CrapsBet{place6}, 0, false},
+		{"place bets stay up after winning", 5, place6, 6, []CrapsOutcome{{place6, 14, 14}}, []CrapsBet{place6}, 5, false},
+		{"place bets lose on a seven out", 5, place6, 7, []CrapsOutcome{{place6, 0, -12}}, nil, 0, true},
+		{"come bet moves to the number", 5, come, 9, nil, []CrapsBet{{Username: "A", Type: CrapsCome, Number: 9, Amount: 10}}, 5, false},
+		{"come odds are off on the come out when it wins", 0, comeOn8, 8, []CrapsOutcome{{comeOn8, 30, 10}}, nil, 8, false},
+		{"come odds are off on the come out when it loses", 0, comeOn8, 7, []CrapsOutcome{{comeOn8, 10, -10}}, nil, 0, false},
+		{"come odds work once the point is set", 5, comeOn8, 8, []CrapsOutcome{{comeOn8, 42, 22}}, nil, 5, false},
+		{"field pays triple on 12", 0, field, 12, []CrapsOutcome{{field, 40, 30}}, nil, 0, false},
+		{"field loses on 7", 0, field, 7, []CrapsOutcome{{field, 0, -10}}, nil, 0, false},
+	}
+
+	for _, test := range tests {
+		table := CrapsTable{Point: test.point, Shooter: "A", Shooters: []string{"A"}, Bets: []CrapsBet{test.bet}}
+
+		outcomes, sevenOut := table.Roll(test.total)
+
+		if !slices.Equal(outcomes, test.want) {
+			t.Errorf("%s: got outcomes %+v, want %+v", test.name, outcomes, test.want)
+		}
+		if !slices.Equal(table.Bets, test.remaining) {
+			t.Errorf("%s: got bets %+v left, want %+v", test.name, table.Bets, test.remaining)
+		}
+		if table.Point != test.wantPoint || sevenOut != test.sevenOut {
+			t.Errorf("%s: got point %d and seven out %v, want %d and %v", test.name, table.Point, sevenOut, test.wantPoint, test.sevenOut)
+		}
+	}
+
+}
+
+func TestCrapsPassDice(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		shooter      string
+		betting      []string
+		wantShooter  string
+		wantShooters []string
+	}{
+		{"next player", "A", []string{"B", "C"}, "B", []string{"B", "C"}},
+		{"skips players without bets", "A", []string{"C"}, "C", []string{"C"}},
+		{"wraps around", "C", []string{"A", "B"}, "A", []string{"A", "B"}},
+		{"back to the shooter", "A", []string{"A"}, "A", []string{"A"}},
+		{"nobody left", "B", nil, "", nil},
+	}
+
+	for _, test := range tests {
+		table := CrapsTable{Shooter: test.shooter, Shooters: []string{"A", "B", "C"}}
+		for _, username := range test.betting {
+			table.Bets = append(table.Bets, CrapsBet{Username: username, Type: CrapsPlace, Number: 6, Amount: 6})
+		}
+
+		table.passDice()
+
+		if table.Shooter != test.wantShooter || !slices.Equal(table.Shooters, test.wantShooters) {
+			t.Errorf("%s: got %q shooting from %v, want %q from %v", test.name, table.Shooter, table.Shooters, test.wantShooter, test.wantShooters)
+		}
+	}
+
+}
@@ -0,0 +1,437 @@
+// This file implements crash. Players bet during a short window, then a multiplier climbs until it crashes. Anyone who
+// cashes out before the crash wins their wager times the multiplier, and anyone still in loses their wager.
+// The crash point is provably fair: a hash of the secret server seed is shown before betting opens, and the seed is
+// revealed after the crash so anyone can check the crash point was decided before the round started.
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// CrashBettingWindow is how long players have to bet before the multiplier starts climbing
+	CrashBettingWindow = 15 * time.Second
+	// CrashTick is how often the round message is edited with the new multiplier
+	CrashTick = time.Second
+	// CrashGrowthRate is how quickly the multiplier climbs. It doubles about every 7 seconds.
+	CrashGrowthRate = 0.1
+	// CrashGrace is how long after the crash the round waits for cash outs that were clicked in time but are still
+	// on their way, before it is settled
+	CrashGrace = 2 * time.Second
+	// CrashInstantOdds is the one in this many rounds that crash straight away at 1.00x, which is the house edge
+	CrashInstantOdds = 33
+)
+
+// CrashPointFromSeeds Returns the crash point for a round. The first 52 bits of HMAC-SHA256(server seed, client seed)
+// are taken as a number h, and the crash point is (2^52 * 100 - h) / (2^52 - h) / 100 rounded down to two decimals,
+// except when h is a multiple of CrashInstantOdds, which crashes at 1.00x.
+func CrashPointFromSeeds(serverSeed string, clientSeed string) float64 {
+
+	mac := hmac.New(sha256.New, []byte(serverSeed))
+	mac.Write([]byte(clientSeed))
+	h := binary.BigEndian.Uint64(mac.Sum(nil)[:8]) >> 12
+
+	if h%CrashInstantOdds == 0 {
+		return 1
+	}
+
+	e := float64(uint64(1) << 52)
+	return math.Floor((100*e-float64(h))/(e-float64(h))) / 100
+
+}
+
+// CrashMultiplier Returns the multiplier after the round has been climbing for the given time, rounded down to two decimals
+func CrashMultiplier(elapsed time.Duration) float64 {
+	return math.Floor(math.Exp(CrashGrowthRate*elapsed.Seconds())*100) / 100
+}
+
+// crashDuration Returns how long the multiplier takes to climb to the crash point
+func crashDuration(crashPoint float64) time.Duration {
+	return time.Duration(math.Log(crashPoint) / CrashGrowthRate * float64(time.Second))
+}
+
+// CrashBet A player's bet in a round of crash
+type CrashBet struct {
+	Username string
+	Wager    int
+	EscrowID int64
+	// CashedOut is the multiplier the player cashed out at, or 0 if they haven't
+	CashedOut float64
+	// CashedAt is when the player clicked cash out, taken from the interaction's snowflake
+	CashedAt time.Time
+}
+
+// CrashRound A round of crash in a channel
+type CrashRound struct {
+	mu sync.Mutex
+
+	ChannelID string
+	MessageID string
+	// Locale is the language of the member who opened the round, which the round is shown in
+	Locale string
+
+	// ServerSeed is kept secret until the round crashes. ClientSeed is public, so the server can't pick a seed to suit it.
+	ServerSeed string
+	ClientSeed string
+	CrashPoint float64
+
+	Bets []*CrashBet
+
+	Running bool
+	Settled bool
+	// StartedAt is when the multiplier started climbing, and CrashesAt is when it reaches the crash point.
+	// Both are taken from Discord's snowflakes, so they can be compared with when cash outs were clicked.
+	StartedAt time.Time
+	CrashesAt time.Time
+}
+
+var (
+	// CrashRoundsMap rounds of crash, by channel ID
+	CrashRoundsMap = make(map[string]*CrashRound)
+	crashRoundsMu  sync.Mutex
+)
+
+// NewCrashRound Creates a round with a new random server seed. The client seed should be something public and unique
+// to the round, like the ID of the command that started it.
+func NewCrashRound(channelID string, clientSeed string) *CrashRound {
+
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		log.Fatal(err)
+	}
+
+	round := &CrashRound{ChannelID: channelID, Locale: DefaultLocale, ServerSeed: hex.EncodeToString(seed), ClientSeed: clientSeed}
+	round.CrashPoint = CrashPointFromSeeds(round.ServerSeed, round.ClientSeed)
+
+	return round
+
+}
+
+// Hash Returns the SHA-256 hash of the server seed, shown before the round so it can be checked afterwards
+func (r *CrashRound) Hash() string {
+	sum := sha256.Sum256([]byte(r.ServerSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// Bet Returns the player's bet in the round, or nil if they haven't bet
+func (r *CrashRound) Bet(username string) *CrashBet {
+	for _, bet := range r.Bets {
+		if bet.Username == username {
+			return bet
+		}
+	}
+	return nil
+}
+
+// Start Starts the multiplier climbing from the given time
+func (r *CrashRound) Start(at time.Time) {
+	r.Running = true
+	r.StartedAt = at
+	r.CrashesAt = at.Add(crashDuration(r.CrashPoint))
+}
+
+// CashOut Cashes the player out at the multiplier when they clicked. Clicks are judged by when they were made rather
+// than when they arrive, so a click before the crash counts even if it is processed after it.
+// Returns the multiplier, or an error if they can't cash out.
+func (r *CrashRound) CashOut(username string, clickedAt time.Time) (float64, error) {
+
+	bet := r.Bet(username)
+	switch {
+	case bet == nil:
+		return 0, NewLocalizedError("crash.error.no_bet")
+	case !r.Running:
+		return 0, NewLocalizedError("crash.error.not_started")
+	case bet.CashedOut > 0:
+		return 0, NewLocalizedError("crash.error.cashed_out", bet.CashedOut)
+	case r.Settled || !clickedAt.Before(r.CrashesAt):
+		return 0, NewLocalizedError("crash.error.too_late", r.CrashPoint)
+	}
+
+	bet.CashedOut = CrashMultiplier(clickedAt.Sub(r.StartedAt))
+	bet.CashedAt = clickedAt
+
+	return bet.CashedOut, nil
+
+}
+
+// Content Returns the round message for the multiplier at the given time
+func (r *CrashRound) Content(now time.Time) string {
+
+	var sb strings.Builder
+
+	switch {
+	case r.Settled:
+		sb.WriteString(T(r.Locale, "crash.crashed", r.CrashPoint))
+	case r.Running:
+		multiplier := CrashMultiplier(now.Sub(r.StartedAt))
+		if now.After(r.CrashesAt) {
+			multiplier = r.CrashPoint
+		}
+		sb.WriteString(fmt.Sprintf("🚀 **%.2fx**\n\n", multiplier))
+	default:
+		sb.WriteString(T(r.Locale, "crash.starting", int(CrashBettingWindow.Seconds())))
+	}
+
+	// Showing cash outs in the order they were clicked
+	bets := make([]*CrashBet, len(r.Bets))
+	copy(bets, r.Bets)
+	sort.SliceStable(bets, func(i, j int) bool {
+		if (bets[i].CashedOut > 0) != (bets[j].CashedOut > 0) {
+			return bets[i].CashedOut > 0
+		}
+		return bets[i].CashedAt.Before(bets[j].CashedAt)
+	})
+
+	for _, bet := range bets {
+		switch {
+		case bet.CashedOut > 0:
+			sb.WriteString(T(r.Locale, "crash.bet_cashed_out", bet.Username, bet.Wager, bet.CashedOut))
+		case r.Settled:
+			sb.WriteString(T(r.Locale, "crash.bet_lost", bet.Username, bet.Wager))
+		default:
+			sb.WriteString(T(r.Locale, "crash.bet_in", bet.Username, bet.Wager))
+		}
+	}
+
+	sb.WriteString(T(r.Locale, "crash.seeds", r.Hash(), r.ClientSeed))
+	if r.Settled {
+		sb.WriteString(T(r.Locale, "crash.server_seed", r.ServerSeed))
+	}
+
+	return sb.String()
+
+}
+
+// Components Returns the cash out button while the multiplier is climbing
+func (r *CrashRound) Components() []discordgo.MessageComponent {
+
+	if !r.Running || r.Settled {
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: T(r.Locale, "crash.cash_out_button"), Style: discordgo.SuccessButton, CustomID: "crash-cashout"},
+			},
+		},
+	}
+
+}
+
+// Settle Pays out the players who cashed out, and returns a message with the results
+func (r *CrashRound) Settle() string {
+
+	r.Settled = true
+
+	var results []string
+	for _, bet := range r.Bets {
+		net := -bet.Wager
+		if bet.CashedOut > 0 {
+			net = int(float64(bet.Wager)*bet.CashedOut) - bet.Wager
+		}
+
+		// Settling against the escrowed wager, so anything the player won or spent while the round ran is kept
+		player := dba.FindPlayer(bet.Username)
+		if _, ok := dba.SettleEscrow(bet.EscrowID, &player, r.Locale, net); !ok {
+			continue
+		}
+
+		// Letting everyone know who lost the last of their chips and was put back up, like in the other games
+		if net < 0 && player.Chips == MinChips {
+			results = append(results, T(r.Locale, "crash.pity", player.Username, MinChips))
+		}
+	}
+
+	return strings.Join(results, "\n")
+
+}
+
+// run starts the multiplier climbing once betting closes, editing the message as it climbs, and settles the round
+// after it crashes
+func (r *CrashRound) run() {
+
+	time.Sleep(CrashBettingWindow)
+
+	r.mu.Lock()
+	message, err := s.ChannelMessageSendComplex(r.ChannelID, &discordgo.MessageSend{Content: "🚀 **1.00x**"})
+	if err != nil {
+		log.Println(err)
+		r.refund()
+		r.mu.Unlock()
+		return
+	}
+
+	// Using the message's snowflake as the start, so it's on the same clock as the cash out clicks
+	started, _ := discordgo.SnowflakeTimestamp(message.ID)
+	r.MessageID = message.ID
+	r.Start(started)
+	r.edit(time.Now())
+	crashesAt := r.CrashesAt
+	r.mu.Unlock()
+
+	for time.Now().Before(crashesAt) {
+		time.Sleep(CrashTick)
+		r.mu.Lock()
+		r.edit(time.Now())
+		r.mu.Unlock()
+	}
+
+	// Waiting for cash outs that were clicked before the crash to arrive
+	time.Sleep(CrashGrace)
+
+	r.mu.Lock()
+	results := r.Settle()
+	r.edit(time.Now())
+	r.mu.Unlock()
+
+	if results != "" {
+		_, _ = s.ChannelMessageSend(r.ChannelID, results)
+	}
+
+	crashRoundsMu.Lock()
+	delete(CrashRoundsMap, r.ChannelID)
+	crashRoundsMu.Unlock()
+
+}
+
+// edit updates the round message. The round must be locked.
+func (r *CrashRound) edit(now time.Time) {
+
+	content := r.Content(now)
+	components := r.Components()
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		Content:    &content,
+		Components: components,
+		ID:         r.MessageID,
+		Channel:    r.ChannelID,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// refund gives every player their wager back, when a round can't be played. The round must be locked.
+func (r *CrashRound) refund() {
+	for _, bet := range r.Bets {
+		dba.RefundEscrow(bet.EscrowID)
+	}
+	crashRoundsMu.Lock()
+	delete(CrashRoundsMap, r.ChannelID)
+	crashRoundsMu.Unlock()
+}
+
+// CrashCommand handles the /crash command, betting in the channel's round and opening one if there isn't one
+func CrashCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	wager := int(i.ApplicationCommandData().Options[0].IntValue())
+	locale := LocaleFor(i)
+
+	player := dba.FindPlayer(i.Member.User.Username)
+	if player.Chips < wager {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", player.Chips))
+		return
+	}
+
+	crashRoundsMu.Lock()
+	round, ok := CrashRoundsMap[i.ChannelID]
+	opening := !ok
+	if opening {
+		round = NewCrashRound(i.ChannelID, i.ID)
+		round.Locale = locale
+		CrashRoundsMap[i.ChannelID] = round
+	}
+	crashRoundsMu.Unlock()
+
+	round.mu.Lock()
+	defer round.mu.Unlock()
+
+	if round.Running {
+		RespondEphemeral(i, T(locale, "crash.already_started"))
+		return
+	}
+	if round.Bet(player.Username) != nil {
+		RespondEphemeral(i, T(locale, "crash.already_bet"))
+		return
+	}
+
+	// Holding the wager in escrow until the round is settled, so it can be refunded if the bot stops mid round
+	bet := &CrashBet{Username: player.Username, Wager: wager}
+	escrowID, err := dba.EscrowChips(&player, wager)
+	if err != nil {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", dba.GetChipTotal(player.Username)))
+		return
+	}
+	bet.EscrowID = escrowID
+	round.Bets = append(round.Bets, bet)
+
+	message := T(round.Locale, "crash.bets", player.Username, wager)
+	if opening {
+		message = round.Content(time.Now()) + "\n\n" + message
+		go round.run()
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: message,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// CrashCashOutButton handles the cash out button. The click is timed by the interaction's snowflake, which is when
+// Discord received it, so clicks are judged in the order they were made.
+func CrashCashOutButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	locale := LocaleFor(i)
+
+	crashRoundsMu.Lock()
+	round, ok := CrashRoundsMap[i.ChannelID]
+	crashRoundsMu.Unlock()
+
+	if !ok {
+		RespondEphemeral(i, T(locale, "crash.over"))
+		return
+	}
+
+	clickedAt, err := discordgo.SnowflakeTimestamp(i.ID)
+	if err != nil {
+		log.Println(err)
+		clickedAt = time.Now()
+	}
+
+	round.mu.Lock()
+	if round.MessageID != i.Message.ID {
+		round.mu.Unlock()
+		RespondEphemeral(i, T(locale, "crash.over"))
+		return
+	}
+	multiplier, err := round.CashOut(i.Member.User.Username, clickedAt)
+	round.mu.Unlock()
+
+	if err != nil {
+		RespondEphemeral(i, T(locale, "crash.cant_cash_out", ErrorIn(locale, err)))
+		return
+	}
+
+	RespondEphemeral(i, T(locale, "crash.cashed_out", multiplier))
+
+}
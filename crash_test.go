@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestCrashPointFromSeeds(t *testing.T) {
+
+	// Players check rounds with the published formula, so the crash point for a pair of seeds must never change
+	tests := []struct {
+		serverSeed string
+		clientSeed string
+		want       float64
+	}{
+		{"server", "client", 1.66},
+		{"abc", "1", 2.03},
+	}
+
+	for _, test := range tests {
+		if got := CrashPointFromSeeds(test.serverSeed, test.clientSeed); got != test.want {
+			t.Errorf("CrashPointFromSeeds(%q, %q) = %.2f, want %.2f", test.serverSeed, test.clientSeed, got, test.want)
+		}
+	}
+
+}
+
+func TestCrashPointDistribution(t *testing.T) {
+
+	// A crash point of at least x should come up about (1 - 1/CrashInstantOdds) / x of the time, and 1.00x should
+	// come up about 1/CrashInstantOdds of the time plus the 1% of rounds that round down to it
+	const rounds = 20000
+	doubled, instant := 0, 0
+	for i := 0; i < rounds; i++ {
+		point := CrashPointFromSeeds("seed", strconv.Itoa(i))
+		if point < 1 {
+			t.Fatalf("round %d crashed at %.2f, below 1.00x", i, point)
+		}
+		if point >= 2 {
+			doubled++
+		}
+		if point == 1 {
+			instant++
+		}
+	}
+
+	if share := float64(doubled) / rounds; share < 0.46 || share > 0.50 {
+		t.Errorf("%.3f of rounds reached 2.00x, want about 0.48", share)
+	}
+	if share := float64(instant) / rounds; share < 0.03 || share > 0.05 {
+		t.Errorf("%.3f of rounds crashed at 1.00x, want about 0.04", share)
+	}
+
+}
+
+func TestCrashMultiplier(t *testing.T) {
+
+	if got := CrashMultiplier(0); got != 1 {
+		t.Errorf("CrashMultiplier(0) = %.2f, want 1.00", got)
+	}
+
+	// The multiplier reaches the crash point when the round has run for its duration, give or take the rounding down
+	for _, point := range []float64{1.5, 2.5, 10, 100} {
+		if got := CrashMultiplier(crashDuration(point)); got < point-0.01 || got > point {
+			t.Errorf("CrashMultiplier(crashDuration(%.2f)) = %.2f", point, got)
+		}
+	}
+
+}
@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"strings"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -14,6 +17,9 @@ type DBA struct {
 	conn *sql.DB
 }
 
+// ErrNotEnoughChips is returned when a player doesn't have the chips to cover what's being taken from them
+var ErrNotEnoughChips = errors.New("not enough chips")
+
 // OpenConnection Opens the connection to a sqlite3 database
 func (dba *DBA) OpenConnection(connectionString string) {
 
@@ -27,6 +33,127 @@ func (dba *DBA) OpenConnection(connectionString string) {
 		log.Fatal(err)
 	}
 
+	dba.CreateTables()
+
+}
+
+// CreateTables Creates any tables the program uses that don't exist in the database yet
+func (dba *DBA) CreateTables() {
+
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS "player" (
+			"id"	INTEGER NOT NULL,
+			"username"	TEXT NOT NULL UNIQUE,
+			"chips"	INTEGER NOT NULL,
+			"wins"	INTEGER NOT NULL DEFAULT 0,
+			"ties"	INTEGER NOT NULL DEFAULT 0,
+			"losses"	INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY("id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "trainer_stats" (
+			"player_id"	INTEGER NOT NULL,
+			"category"	TEXT NOT NULL,
+			"correct"	INTEGER NOT NULL DEFAULT 0,
+			"total"	INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY("player_id","category"),
+			FOREIGN KEY("player_id") REFERENCES "player"("id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "counting_stats" (
+			"player_id"	INTEGER NOT NULL,
+			"sessions"	INTEGER NOT NULL DEFAULT 0,
+			"checks"	INTEGER NOT NULL DEFAULT 0,
+			"running_correct"	INTEGER NOT NULL DEFAULT 0,
+			"true_correct"	INTEGER NOT NULL DEFAULT 0,
+			"response_ms"	INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY("player_id"),
+			FOREIGN KEY("player_id") REFERENCES "player"("id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "jackpot" (
+			"id"	INTEGER NOT NULL CHECK("id" = 1),
+			"pool"	REAL NOT NULL,
+			PRIMARY KEY("id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "craps_table" (
+			"channel_id"	TEXT NOT NULL,
+			"point"	INTEGER NOT NULL DEFAULT 0,
+			"shooter"	TEXT NOT NULL DEFAULT '',
+			"shooters"	TEXT NOT NULL DEFAULT '',
+			PRIMARY KEY("channel_id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "craps_bet" (
+			"id"	INTEGER NOT NULL,
+			"channel_id"	TEXT NOT NULL,
+			"username"	TEXT NOT NULL,
+			"type"	TEXT NOT NULL,
+			"number"	INTEGER NOT NULL DEFAULT 0,
+			"amount"	INTEGER NOT NULL,
+			"odds"	INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY("id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "escrow" (
+			"id"	INTEGER NOT NULL,
+			"username"	TEXT NOT NULL,
+			"stake"	INTEGER NOT NULL,
+			PRIMARY KEY("id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "lottery_draw" (
+			"id"	INTEGER NOT NULL,
+			"draws_at"	INTEGER NOT NULL,
+			"drawn"	INTEGER NOT NULL DEFAULT 0,
+			"winner"	TEXT NOT NULL DEFAULT '',
+			"pot"	INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY("id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "lottery_ticket" (
+			"draw_id"	INTEGER NOT NULL,
+			"username"	TEXT NOT NULL,
+			"tickets"	INTEGER NOT NULL,
+			"chips"	INTEGER NOT NULL,
+			PRIMARY KEY("draw_id","username"),
+			FOREIGN KEY("draw_id") REFERENCES "lottery_draw"("id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "locale_setting" (
+			"scope"	TEXT NOT NULL,
+			"id"	TEXT NOT NULL,
+			"locale"	TEXT NOT NULL,
+			PRIMARY KEY("scope","id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "reward_claim" (
+			"username"	TEXT NOT NULL,
+			"kind"	TEXT NOT NULL,
+			"claimed_at"	INTEGER NOT NULL DEFAULT 0,
+			"streak"	INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY("username","kind")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "chip_transfer" (
+			"id"	INTEGER NOT NULL,
+			"sender"	TEXT NOT NULL,
+			"recipient"	TEXT NOT NULL,
+			"amount"	INTEGER NOT NULL,
+			"tax"	INTEGER NOT NULL,
+			"sent_at"	INTEGER NOT NULL,
+			PRIMARY KEY("id")
+		)`,
+		`CREATE TABLE IF NOT EXISTS "loan" (
+			"id"	INTEGER NOT NULL,
+			"username"	TEXT NOT NULL,
+			"principal"	INTEGER NOT NULL,
+			"balance"	REAL NOT NULL,
+			"taken_at"	INTEGER NOT NULL,
+			"accrued_at"	INTEGER NOT NULL,
+			"due_at"	INTEGER NOT NULL,
+			"repaid_at"	INTEGER NOT NULL DEFAULT 0,
+			"defaulted"	INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY("id")
+		)`,
+	}
+
+	for _, table := range tables {
+		if _, err := dba.conn.Exec(table); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 }
 
 // FindPlayer Queries the database for a player and returns their info. OR creates a new player if player cannot be found
@@ -88,6 +215,15 @@ func (dba *DBA) UpdatePlayer(player Player) {
 
 }
 
+// AddChips adds chips to the player's saved chips, without overwriting anything else that changed them
+func (dba *DBA) AddChips(username string, chips int) {
+
+	if _, err := dba.conn.Exec("UPDATE player SET chips = chips + ? WHERE username = ?", chips, username); err != nil {
+		log.Fatal(err)
+	}
+
+}
+
 // GetChipTotal queries the database for a player using username, and returns their chip total.
 func (dba *DBA) GetChipTotal(username string) int {
 
@@ -150,3 +286,1074 @@ func (dba *DBA) GetLeaderboard(leaderboardType int) []Player {
 	return leaderboard
 
 }
+
+// RecordTrainerDecision adds a decision made in the blackjack trainer to the player's accuracy for that hand category
+func (dba *DBA) RecordTrainerDecision(player Player, category string, correct bool) {
+
+	correctCount := 0
+	if correct {
+		correctCount = 1
+	}
+
+	_, err := dba.conn.Exec(
+		`INSERT INTO trainer_stats VALUES(?, ?, ?, 1)
+		ON CONFLICT(player_id, category) DO UPDATE SET correct = correct + excluded.correct, total = total + 1`,
+		player.ID, category, correctCount)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+}
+
+// GetTrainerStats queries the database for the player's blackjack trainer accuracy in each hand category
+func (dba *DBA) GetTrainerStats(player Player) []TrainerStat {
+
+	rows, err := dba.conn.Query("SELECT category, correct, total FROM trainer_stats WHERE player_id = ? ORDER BY category ASC", player.ID)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer rows.Close()
+
+	var stats []TrainerStat
+	stat := TrainerStat{}
+
+	for rows.Next() {
+
+		err = rows.Scan(&stat.Category, &stat.Correct, &stat.Total)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats
+
+}
+
+// RecordCountingSession adds the results of a card counting trainer session to the player's totals
+func (dba *DBA) RecordCountingSession(player Player, stats CountingStats) {
+
+	_, err := dba.conn.Exec(
+		`INSERT INTO counting_stats VALUES(?, 1, ?, ?, ?, ?)
+		ON CONFLICT(player_id) DO UPDATE SET
+			sessions = sessions + 1,
+			checks = checks + excluded.checks,
+			running_correct = running_correct + excluded.running_correct,
+			true_correct = true_correct + excluded.true_correct,
+			response_ms = response_ms + excluded.response_ms`,
+		player.ID, stats.Checks, stats.RunningCorrect, stats.TrueCorrect, stats.ResponseTime.Milliseconds())
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+}
+
+// GetCountingStats queries the database for the player's card counting trainer totals.
+// Returns false if the player has never finished a session.
+func (dba *DBA) GetCountingStats(player Player) (CountingStats, bool) {
+
+	row := dba.conn.QueryRow("SELECT sessions, checks, running_correct, true_correct, response_ms FROM counting_stats WHERE player_id = ?", player.ID)
+
+	var stats CountingStats
+	var responseMs int64
+	err := row.Scan(&stats.Sessions, &stats.Checks, &stats.RunningCorrect, &stats.TrueCorrect, &responseMs)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return stats, false
+	} else if err != nil {
+		log.Fatal(err)
+	}
+
+	stats.ResponseTime = time.Duration(responseMs) * time.Millisecond
+
+	return stats, true
+
+}
+
+// GetJackpot queries the database for the number of chips in the jackpot pool.
+// If the pool hasn't been started yet, it is started with the seed amount.
+func (dba *DBA) GetJackpot(seed int) float64 {
+
+	_, err := dba.conn.Exec("INSERT OR IGNORE INTO jackpot VALUES(1, ?)", seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var pool float64
+	if err = dba.conn.QueryRow("SELECT pool FROM jackpot WHERE id = 1").Scan(&pool); err != nil {
+		log.Fatal(err)
+	}
+
+	return pool
+
+}
+
+// ClaimJackpot takes a share of the jackpot pool and returns the whole number of chips won.
+// If the whole pool is won it is reset to the seed amount. Done in a transaction so the pool can't be won twice.
+func (dba *DBA) ClaimJackpot(share float64, seed int) int {
+
+	// Making sure the pool exists before claiming from it
+	dba.GetJackpot(seed)
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var pool float64
+	if err = tx.QueryRow("SELECT pool FROM jackpot WHERE id = 1").Scan(&pool); err != nil {
+		log.Fatal(err)
+	}
+
+	won := int(pool * share)
+	remaining := pool - float64(won)
+	if share >= 1 {
+		remaining = float64(seed)
+	}
+
+	if _, err = tx.Exec("UPDATE jackpot SET pool = ? WHERE id = 1", remaining); err != nil {
+		log.Fatal(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	return won
+
+}
+
+// GetCrapsTable queries the database for the craps table in a channel, with every bet on it.
+// If there's no table in the channel yet, an empty one waiting for a come out roll is returned.
+func (dba *DBA) GetCrapsTable(channelID string) CrapsTable {
+
+	table := CrapsTable{ChannelID: channelID}
+
+	var shooters string
+	row := dba.conn.QueryRow("SELECT point, shooter, shooters FROM craps_table WHERE channel_id = ?", channelID)
+	err := row.Scan(&table.Point, &table.Shooter, &shooters)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Fatal(err)
+	}
+	if shooters != "" {
+		table.Shooters = strings.Split(shooters, ",")
+	}
+
+	rows, err := dba.conn.Query("SELECT username, type, number, amount, odds FROM craps_bet WHERE channel_id = ? ORDER BY id ASC", channelID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer rows.Close()
+
+	bet := CrapsBet{}
+
+	for rows.Next() {
+
+		err = rows.Scan(&bet.Username, &bet.Type, &bet.Number, &bet.Amount, &bet.Odds)
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		table.Bets = append(table.Bets, bet)
+	}
+
+	return table
+
+}
+
+// saveCrapsTable saves the state of a craps table and replaces the bets on it, as part of a transaction
+func saveCrapsTable(tx *sql.Tx, table CrapsTable) {
+
+	_, err := tx.Exec(
+		`INSERT INTO craps_table VALUES(?, ?, ?, ?)
+		ON CONFLICT(channel_id) DO UPDATE SET point = excluded.point, shooter = excluded.shooter, shooters = excluded.shooters`,
+		table.ChannelID, table.Point, table.Shooter, strings.Join(table.Shooters, ","))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err = tx.Exec("DELETE FROM craps_bet WHERE channel_id = ?", table.ChannelID); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, bet := range table.Bets {
+		_, err = tx.Exec("INSERT INTO craps_bet VALUES(NULL, ?, ?, ?, ?, ?, ?)",
+			table.ChannelID, bet.Username, bet.Type, bet.Number, bet.Amount, bet.Odds)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+}
+
+// PlaceCrapsBet takes the chips for a bet from the player and saves the craps table with the bet on it. Done in a
+// transaction, so the chips are never taken without the bet being saved, and a table is never saved with only some
+// of its bets. The player passed in is updated too. Returns ErrNotEnoughChips if the player's saved chips don't cover
+// the bet.
+func (dba *DBA) PlaceCrapsBet(player *Player, table CrapsTable, amount int) error {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec("UPDATE player SET chips = chips - ? WHERE id = ? AND chips >= ?", amount, player.ID, amount)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if updated, _ := res.RowsAffected(); updated == 0 {
+		return ErrNotEnoughChips
+	}
+
+	saveCrapsTable(tx, table)
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	player.Chips -= amount
+
+	return nil
+
+}
+
+// SettleCrapsRoll saves the craps table after a roll and gives each player the chips returned by their bets, adding
+// each bet's result to their wins, ties or losses. Players left with no chips and no bets on the table are put back
+// up to MinChips. Done in a transaction, so the table is never saved without its bets being paid.
+// Returns the usernames of the players who were put back up to MinChips.
+func (dba *DBA) SettleCrapsRoll(table CrapsTable, outcomes []CrapsOutcome) []string {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	saveCrapsTable(tx, table)
+
+	var usernames []string
+	for _, outcome := range outcomes {
+		// Taking the jackpot's share of the bet out of what the player gets back
+		net, contribution := jackpotContribution(outcome.Bet.Amount, outcome.Net)
+		if contribution > 0 {
+			if _, err = tx.Exec("UPDATE jackpot SET pool = pool + ? WHERE id = 1", contribution); err != nil {
+				log.Fatal(err)
+			}
+			outcome.Returned += net - outcome.Net
+			outcome.Net = net
+		}
+
+		var result Player
+		result.AddResult(outcome.Net)
+
+		_, err = tx.Exec("UPDATE player SET chips = chips + ?, wins = wins + ?, ties = ties + ?, losses = losses + ? WHERE username = ?",
+			outcome.Returned, result.Wins, result.Ties, result.Losses, outcome.Bet.Username)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if !slices.Contains(usernames, outcome.Bet.Username) {
+			usernames = append(usernames, outcome.Bet.Username)
+		}
+	}
+
+	// Taking pity on players who lost the last of their chips, like in the other games
+	var pitied []string
+	for _, username := range usernames {
+		if table.HasBets(username) {
+			continue
+		}
+		res, err := tx.Exec("UPDATE player SET chips = ? WHERE username = ? AND chips <= 0", MinChips, username)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if updated, _ := res.RowsAffected(); updated > 0 {
+			pitied = append(pitied, username)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	return pitied
+
+}
+
+// EscrowChips takes chips from the player and holds them in escrow, so they can't be spent while a game between
+// players is waiting to be settled. The player passed in is updated too. Returns the ID of the escrow, or
+// ErrNotEnoughChips if the player's saved chips don't cover the stake.
+func (dba *DBA) EscrowChips(player *Player, stake int) (int64, error) {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec("UPDATE player SET chips = chips - ? WHERE id = ? AND chips >= ?", stake, player.ID, stake)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if updated, _ := res.RowsAffected(); updated == 0 {
+		return 0, ErrNotEnoughChips
+	}
+
+	res, err = tx.Exec("INSERT INTO escrow VALUES(NULL, ?, ?)", player.Username, stake)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	player.Chips -= stake
+
+	return id, nil
+
+}
+
+// RefundEscrow gives the chips held in escrow back to the player they were taken from.
+// Returns false if the escrow was already released or refunded.
+func (dba *DBA) RefundEscrow(id int64) bool {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var username string
+	var stake int
+	err = tx.QueryRow("SELECT username, stake FROM escrow WHERE id = ?", id).Scan(&username, &stake)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false
+	} else if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err = tx.Exec("UPDATE player SET chips = chips + ? WHERE username = ?", stake, username); err != nil {
+		log.Fatal(err)
+	}
+	if _, err = tx.Exec("DELETE FROM escrow WHERE id = ?", id); err != nil {
+		log.Fatal(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	return true
+
+}
+
+// SetEscrowStake changes the chips held in an escrow, for games where the stake goes up and down as it's played
+func (dba *DBA) SetEscrowStake(id int64, stake int) {
+
+	if _, err := dba.conn.Exec("UPDATE escrow SET stake = ? WHERE id = ?", stake, id); err != nil {
+		log.Fatal(err)
+	}
+
+}
+
+// CashOutEscrow releases the escrow and pays the player it was held for the chips they're leaving the game with.
+// Their wins, ties or losses go up by one for the net they won or lost over the whole game. Done in a transaction, so
+// the chips can't be lost between the release and the payout. Returns false if the escrow was already released.
+func (dba *DBA) CashOutEscrow(id int64, chips int, net int) bool {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var username string
+	err = tx.QueryRow("SELECT username FROM escrow WHERE id = ?", id).Scan(&username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false
+	} else if err != nil {
+		log.Fatal(err)
+	}
+
+	var result Player
+	result.AddResult(net)
+
+	_, err = tx.Exec("UPDATE player SET chips = chips + ?, wins = wins + ?, ties = ties + ?, losses = losses + ? WHERE username = ?",
+		chips, result.Wins, result.Ties, result.Losses, username)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err = tx.Exec("DELETE FROM escrow WHERE id = ?", id); err != nil {
+		log.Fatal(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	return true
+
+}
+
+// SettleEscrow releases the escrow a game's wager was held in and settles the game's net against it, so a loss comes
+// out of the escrow rather than the chips the player has now. The jackpot's share of the stake comes out of the net as
+// in jackpotContribution, the stake and the net are added to the player's saved chips, their wins, ties or losses go
+// up by one, and the pity floor is applied as in Player.ApplyNetIn. Done in a
+// transaction, so the chips can't be lost between the release and the payout. The player passed in is replaced with
+// the saved player. Returns the message from Player.ApplyNetIn, and false if the escrow was already released.
+func (dba *DBA) SettleEscrow(id int64, player *Player, locale string, net int) (string, bool) {
+	return dba.SettleEscrows([]int64{id}, player, locale, net)
+}
+
+// SettleEscrows does the same as SettleEscrow for a game with more than one stake held in escrow, settling the net
+// for all of them together. Nothing is settled if any of them was already released.
+func (dba *DBA) SettleEscrows(ids []int64, player *Player, locale string, net int) (string, bool) {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stakes := 0
+	for _, id := range ids {
+		var stake int
+		err = tx.QueryRow("SELECT stake FROM escrow WHERE id = ?", id).Scan(&stake)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		stakes += stake
+
+		// Deleting as we go so the player can't change until the escrow is settled
+		res, err := tx.Exec("DELETE FROM escrow WHERE id = ?", id)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if released, _ := res.RowsAffected(); released == 0 {
+			return "", false
+		}
+	}
+
+	// The stakes are the wager, so the jackpot's share comes out of them once the game is settled
+	net, contribution := jackpotContribution(stakes, net)
+	if contribution > 0 {
+		if _, err = tx.Exec("UPDATE jackpot SET pool = pool + ? WHERE id = 1", contribution); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var result Player
+	result.AddResult(net)
+
+	_, err = tx.Exec("UPDATE player SET wins = wins + ?, ties = ties + ?, losses = losses + ? WHERE id = ?",
+		result.Wins, result.Ties, result.Losses, player.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var saved Player
+	err = tx.QueryRow("SELECT * FROM player WHERE id = ?", player.ID).
+		Scan(&saved.ID, &saved.Username, &saved.Chips, &saved.Wins, &saved.Ties, &saved.Losses)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	before := saved.Chips
+	saved.Chips += stakes
+	message := saved.ApplyNetIn(locale, net)
+
+	if _, err = tx.Exec("UPDATE player SET chips = chips + ? WHERE id = ?", saved.Chips-before, saved.ID); err != nil {
+		log.Fatal(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	*player = saved
+
+	return message, true
+
+}
+
+// SettleDuel releases the escrows holding the stakes of a duel and pays the pot to the winner, adding a win for them
+// and a loss for the loser. A loser left with no chips is put back up to MinChips. On a draw, each stake goes back to
+// the player it was taken from and both players get a tie. Done in a transaction, so the stakes can't be lost between
+// the release and the payout. Returns the saved winner, the pot, and whether the loser was put back up to MinChips.
+// Nothing is settled, and false is returned, if any of the escrows was already released.
+func (dba *DBA) SettleDuel(ids []int64, winner string, loser string, draw bool) (Player, int, bool, bool) {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	pot := 0
+	for _, id := range ids {
+		var username string
+		var stake int
+		err = tx.QueryRow("SELECT username, stake FROM escrow WHERE id = ?", id).Scan(&username, &stake)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Player{}, 0, false, false
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		pot += stake
+
+		if _, err = tx.Exec("DELETE FROM escrow WHERE id = ?", id); err != nil {
+			log.Fatal(err)
+		}
+		if draw {
+			if _, err = tx.Exec("UPDATE player SET chips = chips + ? WHERE username = ?", stake, username); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	pitied := false
+	if draw {
+		if _, err = tx.Exec("UPDATE player SET ties = ties + 1 WHERE username IN (?, ?)", winner, loser); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		if _, err = tx.Exec("UPDATE player SET chips = chips + ?, wins = wins + 1 WHERE username = ?", pot, winner); err != nil {
+			log.Fatal(err)
+		}
+		if _, err = tx.Exec("UPDATE player SET losses = losses + 1 WHERE username = ?", loser); err != nil {
+			log.Fatal(err)
+		}
+
+		// Taking pity on the loser if that was the last of their chips, like in the other games
+		res, err := tx.Exec("UPDATE player SET chips = ? WHERE username = ? AND chips <= 0", MinChips, loser)
+		if err != nil {
+			log.Fatal(err)
+		}
+		updated, _ := res.RowsAffected()
+		pitied = updated > 0
+	}
+
+	var saved Player
+	err = tx.QueryRow("SELECT * FROM player WHERE username = ?", winner).
+		Scan(&saved.ID, &saved.Username, &saved.Chips, &saved.Wins, &saved.Ties, &saved.Losses)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	return saved, pot, pitied, true
+
+}
+
+// RefundAllEscrow gives back every escrowed stake. Games between players are lost when the bot restarts, so this is
+// done on startup. Returns the number of stakes refunded.
+func (dba *DBA) RefundAllEscrow() int {
+
+	rows, err := dba.conn.Query("SELECT id FROM escrow")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			log.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+
+	refunded := 0
+	for _, id := range ids {
+		if dba.RefundEscrow(id) {
+			refunded++
+		}
+	}
+
+	return refunded
+
+}
+
+// GetLotteryDraw queries the database for the next lottery draw that hasn't been drawn yet.
+// If there isn't one, a new draw is scheduled for the given time.
+func (dba *DBA) GetLotteryDraw(drawsAt time.Time) LotteryDraw {
+
+	_, err := dba.conn.Exec(
+		`INSERT INTO lottery_draw(draws_at) SELECT ? WHERE NOT EXISTS (SELECT 1 FROM lottery_draw WHERE drawn = 0)`,
+		drawsAt.Unix())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var draw LotteryDraw
+	var unix int64
+	row := dba.conn.QueryRow("SELECT id, draws_at FROM lottery_draw WHERE drawn = 0 ORDER BY draws_at ASC LIMIT 1")
+	if err = row.Scan(&draw.ID, &unix); err != nil {
+		log.Fatal(err)
+	}
+	draw.DrawsAt = time.Unix(unix, 0)
+
+	return draw
+
+}
+
+// BuyLotteryTickets takes the cost of the tickets from the player and adds the tickets to the draw.
+// The player passed in is updated too. Returns ErrNotEnoughChips if the player's saved chips don't cover the cost,
+// or ErrLotteryDrawn if the draw has already been drawn.
+func (dba *DBA) BuyLotteryTickets(player *Player, drawID int, tickets int, cost int) error {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec("UPDATE player SET chips = chips - ? WHERE id = ? AND chips >= ?", cost, player.ID, cost)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if updated, _ := res.RowsAffected(); updated == 0 {
+		return ErrNotEnoughChips
+	}
+
+	var drawn bool
+	if err = tx.QueryRow("SELECT drawn FROM lottery_draw WHERE id = ?", drawID).Scan(&drawn); err != nil {
+		log.Fatal(err)
+	}
+	if drawn {
+		return ErrLotteryDrawn
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO lottery_ticket VALUES(?, ?, ?, ?)
+		ON CONFLICT(draw_id, username) DO UPDATE SET tickets = tickets + excluded.tickets, chips = chips + excluded.chips`,
+		drawID, player.Username, tickets, cost)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	player.Chips -= cost
+
+	return nil
+
+}
+
+// GetLotteryTickets queries the database for everyone's tickets in a draw
+func (dba *DBA) GetLotteryTickets(drawID int) []LotteryTickets {
+
+	rows, err := dba.conn.Query("SELECT username, tickets, chips FROM lottery_ticket WHERE draw_id = ? ORDER BY username ASC", drawID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer rows.Close()
+
+	var tickets []LotteryTickets
+	entry := LotteryTickets{}
+
+	for rows.Next() {
+
+		if err = rows.Scan(&entry.Username, &entry.Tickets, &entry.Chips); err != nil {
+			log.Fatal(err)
+		}
+
+		tickets = append(tickets, entry)
+	}
+
+	return tickets
+
+}
+
+// FinishLotteryDraw marks the draw as drawn, picks the winner from everyone's tickets and pays them the pot.
+// Done in a transaction, with the draw marked first, so tickets can't be bought once the winner is being picked and
+// the pot can't be paid twice. Returns the winner, who is empty if nobody bought a ticket, and the pot.
+// Returns false if the draw was already drawn.
+func (dba *DBA) FinishLotteryDraw(drawID int, pick func([]LotteryTickets) string) (string, int, bool) {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec("UPDATE lottery_draw SET drawn = 1 WHERE id = ? AND drawn = 0", drawID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if updated, _ := res.RowsAffected(); updated == 0 {
+		return "", 0, false
+	}
+
+	rows, err := tx.Query("SELECT username, tickets, chips FROM lottery_ticket WHERE draw_id = ? ORDER BY username ASC", drawID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var tickets []LotteryTickets
+	entry := LotteryTickets{}
+	pot := 0
+
+	for rows.Next() {
+
+		if err = rows.Scan(&entry.Username, &entry.Tickets, &entry.Chips); err != nil {
+			log.Fatal(err)
+		}
+
+		tickets = append(tickets, entry)
+		pot += entry.Chips
+	}
+	_ = rows.Close()
+
+	winner := pick(tickets)
+
+	if _, err = tx.Exec("UPDATE lottery_draw SET winner = ?, pot = ? WHERE id = ?", winner, pot, drawID); err != nil {
+		log.Fatal(err)
+	}
+
+	if winner != "" {
+		if _, err = tx.Exec("UPDATE player SET chips = chips + ? WHERE username = ?", pot, winner); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	return winner, pot, true
+
+}
+
+// GetLocales queries the database for the language the player picked, and the language their server picked.
+// Either is an empty string if it hasn't been set.
+func (dba *DBA) GetLocales(username string, guildID string) (string, string) {
+
+	rows, err := dba.conn.Query(
+		"SELECT scope, locale FROM locale_setting WHERE (scope = ? AND id = ?) OR (scope = ? AND id = ?)",
+		LocaleScopeUser, username, LocaleScopeGuild, guildID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var userLocale, guildLocale string
+
+	for rows.Next() {
+
+		var scope, locale string
+		if err = rows.Scan(&scope, &locale); err != nil {
+			log.Fatal(err)
+		}
+
+		if scope == LocaleScopeUser {
+			userLocale = locale
+		} else {
+			guildLocale = locale
+		}
+	}
+
+	return userLocale, guildLocale
+
+}
+
+// SetLocale sets the language for a player, by username, or a server, by guild ID.
+// An empty locale removes the setting, so the next language down is used.
+func (dba *DBA) SetLocale(scope string, id string, locale string) {
+
+	var err error
+
+	if locale == "" {
+		_, err = dba.conn.Exec("DELETE FROM locale_setting WHERE scope = ? AND id = ?", scope, id)
+	} else {
+		_, err = dba.conn.Exec(
+			`INSERT INTO locale_setting VALUES(?, ?, ?)
+			ON CONFLICT(scope, id) DO UPDATE SET locale = excluded.locale`,
+			scope, id, locale)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+}
+
+// ClaimReward gives the player a reward if it's off cooldown, saving when it was claimed and the player's streak.
+// The streak goes up if the last claim was within the streak window, and otherwise starts again at 1. The chips come
+// from chips, given the streak. Done in a transaction, so a reward can't be claimed twice at once.
+// The player passed in is updated too.
+func (dba *DBA) ClaimReward(player *Player, kind string, cooldown time.Duration, streakWindow time.Duration, now time.Time, chips func(streak int) int) RewardClaim {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Writing first so the claim holds the lock until it's done
+	_, err = tx.Exec("INSERT INTO reward_claim VALUES(?, ?, 0, 0) ON CONFLICT(username, kind) DO NOTHING", player.Username, kind)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var claimedAt int64
+	var streak int
+	err = tx.QueryRow("SELECT claimed_at, streak FROM reward_claim WHERE username = ? AND kind = ?", player.Username, kind).Scan(&claimedAt, &streak)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	last := time.Unix(claimedAt, 0)
+	if claimedAt > 0 && now.Sub(last) < cooldown {
+		return RewardClaim{Streak: streak, Next: last.Add(cooldown)}
+	}
+
+	if claimedAt > 0 && now.Sub(last) < streakWindow {
+		streak++
+	} else {
+		streak = 1
+	}
+
+	claim := RewardClaim{Claimed: true, Chips: chips(streak), Streak: streak, Next: now.Add(cooldown)}
+
+	_, err = tx.Exec("UPDATE reward_claim SET claimed_at = ?, streak = ? WHERE username = ? AND kind = ?", now.Unix(), streak, player.Username, kind)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err = tx.Exec("UPDATE player SET chips = chips + ? WHERE id = ?", claim.Chips, player.ID); err != nil {
+		log.Fatal(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	player.Chips += claim.Chips
+
+	return claim
+
+}
+
+// GetChipsSent queries the ledger for the number of chips the player has given away since the time
+func (dba *DBA) GetChipsSent(username string, since time.Time) int {
+
+	var sent int
+	err := dba.conn.QueryRow("SELECT COALESCE(SUM(amount), 0) FROM chip_transfer WHERE sender = ? AND sent_at >= ?", username, since.Unix()).Scan(&sent)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return sent
+
+}
+
+// TransferChips takes the amount from the sender and gives it to the recipient less the tax, recording it in the
+// ledger. The sender can't give away more than the limit in TransferLimitWindow, unless the limit is 0. Done in a
+// single transaction, so the chips are never taken without being given, and two transfers at once can't both get
+// past the limit. The player passed in is updated too. Returns ErrTransferNotEnoughChips or ErrTransferLimit if the
+// transfer isn't made.
+func (dba *DBA) TransferChips(sender *Player, recipient string, amount int, tax int, limit int, now time.Time) error {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec("UPDATE player SET chips = chips - ? WHERE id = ? AND chips >= ?", amount, sender.ID, amount)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if updated, _ := res.RowsAffected(); updated == 0 {
+		return ErrTransferNotEnoughChips
+	}
+
+	if limit > 0 {
+		var sent int
+		err = tx.QueryRow("SELECT COALESCE(SUM(amount), 0) FROM chip_transfer WHERE sender = ? AND sent_at >= ?",
+			sender.Username, now.Add(-TransferLimitWindow).Unix()).Scan(&sent)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if sent+amount > limit {
+			return ErrTransferLimit
+		}
+	}
+
+	if _, err = tx.Exec("UPDATE player SET chips = chips + ? WHERE username = ?", amount-tax, recipient); err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = tx.Exec("INSERT INTO chip_transfer VALUES(NULL, ?, ?, ?, ?, ?)", sender.Username, recipient, amount, tax, now.Unix())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	sender.Chips -= amount
+
+	return nil
+
+}
+
+// GetLoan queries the database for the player's loan that hasn't been repaid yet. Returns false if they don't have one.
+func (dba *DBA) GetLoan(username string) (Loan, bool) {
+
+	loan := Loan{Username: username}
+	var takenAt, accruedAt, dueAt int64
+
+	err := dba.conn.QueryRow(
+		"SELECT id, principal, balance, taken_at, accrued_at, due_at FROM loan WHERE username = ? AND repaid_at = 0",
+		username).Scan(&loan.ID, &loan.Principal, &loan.Balance, &takenAt, &accruedAt, &dueAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return loan, false
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	loan.TakenAt = time.Unix(takenAt, 0)
+	loan.AccruedAt = time.Unix(accruedAt, 0)
+	loan.DueAt = time.Unix(dueAt, 0)
+
+	return loan, true
+
+}
+
+// GetLoanHistory queries the database for the number of loans the player repaid on time, and the number they
+// defaulted on. A loan still owed after its due date counts as defaulted.
+func (dba *DBA) GetLoanHistory(username string, now time.Time) LoanHistory {
+
+	var history LoanHistory
+
+	err := dba.conn.QueryRow(
+		`SELECT
+			COALESCE(SUM(repaid_at > 0 AND defaulted = 0), 0),
+			COALESCE(SUM(defaulted = 1 OR (repaid_at = 0 AND due_at < ?)), 0)
+		FROM loan WHERE username = ?`,
+		now.Unix(), username).Scan(&history.Repaid, &history.Defaulted)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return history
+
+}
+
+// TakeLoan gives the player the amount as a new loan, due at the time given. The player passed in is updated too.
+// Done in a transaction, so a player can't take out two loans at once. Returns false if they already have a loan.
+func (dba *DBA) TakeLoan(player *Player, amount int, now time.Time, due time.Time) bool {
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err = tx.Exec("UPDATE player SET chips = chips + ? WHERE id = ?", amount, player.ID); err != nil {
+		log.Fatal(err)
+	}
+
+	var outstanding int
+	if err = tx.QueryRow("SELECT COUNT(*) FROM loan WHERE username = ? AND repaid_at = 0", player.Username).Scan(&outstanding); err != nil {
+		log.Fatal(err)
+	}
+	if outstanding > 0 {
+		return false
+	}
+
+	_, err = tx.Exec("INSERT INTO loan VALUES(NULL, ?, ?, ?, ?, ?, ?, 0, 0)",
+		player.Username, amount, float64(amount), now.Unix(), now.Unix(), due.Unix())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	player.Chips += amount
+
+	return true
+
+}
+
+// RepayLoan takes up to the amount from the player to pay off the loan, with the interest added up to now.
+// A loan paid off after its due date is recorded as defaulted. Done in a transaction, and only if the loan hasn't
+// changed since it was read, so a payment can't be counted twice. The player passed in is updated too.
+// Returns the chips paid, and false if the player didn't have the chips or the loan had changed.
+func (dba *DBA) RepayLoan(player *Player, loan Loan, amount int, now time.Time) (int, bool) {
+
+	owed := loan.OwedAt(now)
+	paid := min(amount, loan.Owed(now))
+
+	balance := owed - float64(paid)
+	var repaidAt int64
+	if balance <= 0 {
+		balance = 0
+		repaidAt = now.Unix()
+	}
+
+	tx, err := dba.conn.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(
+		`UPDATE loan SET balance = ?, accrued_at = ?, repaid_at = ?, defaulted = ?
+		WHERE id = ? AND accrued_at = ? AND repaid_at = 0`,
+		balance, now.Unix(), repaidAt, repaidAt > 0 && loan.InDefault(now), loan.ID, loan.AccruedAt.Unix())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if updated, _ := res.RowsAffected(); updated == 0 {
+		return 0, false
+	}
+
+	res, err = tx.Exec("UPDATE player SET chips = chips - ? WHERE id = ? AND chips >= ?", paid, player.ID, paid)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if updated, _ := res.RowsAffected(); updated == 0 {
+		return 0, false
+	}
+
+	if err = tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	player.Chips -= paid
+
+	return paid, true
+
+}
@@ -0,0 +1,313 @@
+// This file implements duels, where one member challenges another to a coin flip, a dice roll or a hand of blackjack
+// for a stake. Both stakes are held in escrow until the duel is settled, so neither player can spend them on anything else.
+package main
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Duel games
+const (
+	DuelCoin      = "coin"
+	DuelDice      = "dice"
+	DuelBlackjack = "blackjack"
+)
+
+// DuelTimeout is how long the challenged member has to accept before the challenge is called off
+const DuelTimeout = 2 * time.Minute
+
+// Duel A challenge from one member to another, waiting to be accepted
+type Duel struct {
+	Challenger   Player
+	ChallengerID string
+	Opponent     string
+	OpponentID   string
+	Game         string
+	Stake        int
+	// EscrowID is the escrow holding the challenger's stake
+	EscrowID int64
+	// Interaction is the command that made the challenge, used to edit the challenge message when it expires
+	Interaction *discordgo.Interaction
+	// Locale is the language of the member who made the challenge, which the duel is shown in
+	Locale string
+}
+
+var (
+	// DuelsMap challenges waiting to be accepted, by the ID of the command that made them
+	DuelsMap = make(map[string]*Duel)
+	duelsMu  sync.Mutex
+)
+
+// takeDuel Removes the duel the button was pressed on from the map and returns it, so only one button can settle it.
+// take is called with the map locked and the duel is only removed if it returns true, so a button that can't settle
+// the duel never takes it out from under the timeout. Returns nil if the duel has already been settled, and false if
+// take refused it.
+func takeDuel(i *discordgo.InteractionCreate, take func(duel *Duel) bool) (*Duel, bool) {
+
+	if i.Message.Interaction == nil {
+		return nil, false
+	}
+
+	duelsMu.Lock()
+	defer duelsMu.Unlock()
+
+	duel, ok := DuelsMap[i.Message.Interaction.ID]
+	if !ok {
+		return nil, false
+	}
+	if !take(duel) {
+		return duel, false
+	}
+	delete(DuelsMap, i.Message.Interaction.ID)
+
+	return duel, true
+
+}
+
+// Play Plays out the duel, returning true if the challenger wins and a description of what happened.
+// Dice are rolled again on a tie, so there's always a winner.
+func (d *Duel) Play() (bool, string) {
+
+	if d.Game == DuelCoin {
+		heads := RNG.Intn(2) == 0
+		if heads {
+			return true, T(d.Locale, "duel.heads", d.Challenger.Username)
+		}
+		return false, T(d.Locale, "duel.tails", d.Opponent)
+	}
+
+	var sb strings.Builder
+	for {
+		challenger := RNG.Intn(6) + RNG.Intn(6) + 2
+		opponent := RNG.Intn(6) + RNG.Intn(6) + 2
+		sb.WriteString(T(d.Locale, "duel.rolls", d.Challenger.Username, challenger, d.Opponent, opponent))
+		if challenger != opponent {
+			return challenger > opponent, sb.String()
+		}
+		sb.WriteString(T(d.Locale, "duel.tie"))
+	}
+
+}
+
+// DuelCommand handles the /duel command, escrowing the challenger's stake and asking the opponent to accept
+func DuelCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	// Getting options and storing in map
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	opponent := optionMap["opponent"].UserValue(s)
+	game := optionMap["game"].StringValue()
+	stake := int(optionMap["stake"].IntValue())
+	locale := LocaleFor(i)
+
+	if opponent.ID == i.Member.User.ID || opponent.Bot {
+		RespondEphemeral(i, T(locale, "duel.another_member"))
+		return
+	}
+
+	challenger := dba.FindPlayer(i.Member.User.Username)
+	if challenger.Chips < stake {
+		RespondEphemeral(i, T(locale, "duel.not_enough_chips", challenger.Chips))
+		return
+	}
+
+	duel := &Duel{
+		Challenger:   challenger,
+		ChallengerID: i.Member.User.ID,
+		Opponent:     opponent.Username,
+		OpponentID:   opponent.ID,
+		Game:         game,
+		Stake:        stake,
+		Interaction:  i.Interaction,
+		Locale:       locale,
+	}
+	escrowID, err := dba.EscrowChips(&duel.Challenger, stake)
+	if err != nil {
+		RespondEphemeral(i, T(locale, "duel.not_enough_chips", dba.GetChipTotal(challenger.Username)))
+		return
+	}
+	duel.EscrowID = escrowID
+
+	name := T(locale, "duel.game."+game)
+
+	// Waiting for the opponent before responding, so the buttons work as soon as they're shown
+	duelsMu.Lock()
+	DuelsMap[i.ID] = duel
+	duelsMu.Unlock()
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: T(locale, "duel.challenge", opponent.ID, challenger.Username, name, stake, int(DuelTimeout.Minutes())),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.Button{Label: T(locale, "duel.accept_button"), Style: discordgo.SuccessButton, CustomID: "duel-accept"},
+						discordgo.Button{Label: T(locale, "duel.decline_button"), Style: discordgo.DangerButton, CustomID: "duel-decline"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+		duelsMu.Lock()
+		_, waiting := DuelsMap[i.ID]
+		delete(DuelsMap, i.ID)
+		duelsMu.Unlock()
+		if waiting {
+			dba.RefundEscrow(duel.EscrowID)
+		}
+		return
+	}
+
+	// Calling the duel off if it isn't accepted in time
+	time.AfterFunc(DuelTimeout, func() {
+		duelsMu.Lock()
+		_, waiting := DuelsMap[duel.Interaction.ID]
+		delete(DuelsMap, duel.Interaction.ID)
+		duelsMu.Unlock()
+
+		if !waiting || !dba.RefundEscrow(duel.EscrowID) {
+			return
+		}
+
+		content := T(duel.Locale, "duel.expired", duel.Challenger.Username, duel.Opponent)
+		components := []discordgo.MessageComponent{}
+		_, err := s.InteractionResponseEdit(duel.Interaction, &discordgo.WebhookEdit{Content: &content, Components: &components})
+		if err != nil {
+			log.Println(err)
+		}
+	})
+
+}
+
+// DuelAcceptButton handles the accept button, escrowing the opponent's stake and playing the duel
+func DuelAcceptButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	// The opponent's stake is escrowed before the duel is taken, so it stays waiting if they can't cover it
+	var opponent Player
+	var opponentEscrow int64
+	var refusal string
+	duel, taken := takeDuel(i, func(duel *Duel) bool {
+		if i.Member.User.ID != duel.OpponentID {
+			refusal = T(LocaleFor(i), "duel.not_yours")
+			return false
+		}
+
+		opponent = dba.FindPlayer(duel.Opponent)
+		var err error
+		opponentEscrow, err = dba.EscrowChips(&opponent, duel.Stake)
+		if err != nil {
+			refusal = T(LocaleFor(i), "duel.cant_accept", dba.GetChipTotal(opponent.Username))
+			return false
+		}
+
+		return true
+	})
+	if duel == nil {
+		RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+		return
+	}
+	if !taken {
+		RespondEphemeral(i, refusal)
+		return
+	}
+
+	// Blackjack duels are played out over several turns, in their own thread
+	if duel.Game == DuelBlackjack {
+		StartBlackjackDuel(i, duel, opponent, opponentEscrow)
+		return
+	}
+
+	challengerWins, message := duel.Play()
+	result := -1
+	if challengerWins {
+		result = 1
+	}
+	message += SettleDuel(duel, []int64{duel.EscrowID, opponentEscrow}, result)
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    i.Message.Content + "\n\n" + message,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// SettleDuel releases both stakes from escrow and pays the pot to the winner. A result of 1 means the challenger
+// won, -1 means the opponent won, and 0 is a draw where both stakes are returned. Returns a message with the result.
+func SettleDuel(duel *Duel, escrows []int64, result int) string {
+
+	winner, loser := duel.Challenger.Username, duel.Opponent
+	if result < 0 {
+		winner, loser = loser, winner
+	}
+
+	saved, pot, pitied, ok := dba.SettleDuel(escrows, winner, loser, result == 0)
+	if !ok {
+		log.Printf("Duel between %s and %s was already settled", duel.Challenger.Username, duel.Opponent)
+		return ""
+	}
+	if result == 0 {
+		return T(duel.Locale, "duel.draw")
+	}
+
+	message := T(duel.Locale, "duel.wins", saved.Username, pot, saved.Chips)
+	if pitied {
+		message += "\n" + T(duel.Locale, "duel.pity", loser, MinChips)
+	}
+
+	return message
+
+}
+
+// DuelDeclineButton handles the decline button. The opponent can decline, or the challenger can call it off.
+// Either way the challenger's stake is returned.
+func DuelDeclineButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	duel, taken := takeDuel(i, func(duel *Duel) bool {
+		return i.Member.User.ID == duel.OpponentID || i.Member.User.ID == duel.ChallengerID
+	})
+	if duel == nil {
+		RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+		return
+	}
+	if !taken {
+		RespondEphemeral(i, T(LocaleFor(i), "duel.not_yours"))
+		return
+	}
+
+	dba.RefundEscrow(duel.EscrowID)
+
+	message := T(duel.Locale, "duel.declined", i.Member.User.Username)
+	if i.Member.User.ID == duel.ChallengerID {
+		message = T(duel.Locale, "duel.called_off", i.Member.User.Username)
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    i.Message.Content + "\n\n" + message + T(duel.Locale, "duel.stake_returned"),
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
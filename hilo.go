@@ -0,0 +1,313 @@
+// This file implements hi-lo. A card is turned over from a deck and the player guesses whether the next card will be
+// higher or lower. Each right guess multiplies their wager by the odds against it, and they can cash out whenever they
+// like, but a wrong guess loses the wager. Aces are low, and a card of the same rank is a push.
+package main
+
+import (
+	"log"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// HiLoHouseEdge is taken off the true odds for every right guess
+const HiLoHouseEdge = 0.02
+
+// HiLo A game of hi-lo being played
+type HiLo struct {
+	mu sync.Mutex
+
+	Player   Player
+	Wager    int
+	EscrowID int64
+	Deck     Deck
+	// Cards are the cards turned over so far, the last of which is the one being guessed from
+	Cards []Card
+	// Multiplier is the product of the odds of every right guess so far, before rounding
+	Multiplier float64
+	// InteractionID is the ID of the command that started the game, to tell which message its buttons are on
+	InteractionID string
+	// Locale is the language the game is shown in
+	Locale string
+	Over   bool
+}
+
+var (
+	// HiLoGamesMap games of hi-lo being played, by the player's username
+	HiLoGamesMap = make(map[string]*HiLo)
+	hiLoGamesMu  sync.Mutex
+)
+
+// NewHiLo Shuffles a deck and turns over the first card
+func NewHiLo(player Player, wager int) *HiLo {
+
+	game := &HiLo{Player: player, Wager: wager, Deck: NewShoe(1), Multiplier: 1, Locale: DefaultLocale}
+	game.Cards = append(game.Cards, game.Deck.DealCard())
+
+	return game
+
+}
+
+// Current Returns the card the next guess is made from
+func (g *HiLo) Current() Card {
+	return g.Cards[len(g.Cards)-1]
+}
+
+// Counts Returns how many of the cards left in the deck are higher, lower and the same rank as the current card
+func (g *HiLo) Counts() (higher int, lower int, same int) {
+
+	current := g.Current().Rank
+
+	for _, card := range g.Deck {
+		switch {
+		case card.Rank > current:
+			higher++
+		case card.Rank < current:
+			lower++
+		default:
+			same++
+		}
+	}
+
+	return higher, lower, same
+
+}
+
+// GuessMultiplier Returns what a right guess multiplies the wager by, or 0 if the guess can't win.
+// Since a card of the same rank is a push, the true odds only count the cards that are higher or lower.
+func (g *HiLo) GuessMultiplier(higher bool) float64 {
+
+	up, down, _ := g.Counts()
+
+	wins := down
+	if higher {
+		wins = up
+	}
+	if wins == 0 {
+		return 0
+	}
+
+	return float64(up+down) / float64(wins) * (1 - HiLoHouseEdge)
+
+}
+
+// Payout Returns what cashing out now pays, rounded down to the chip
+func (g *HiLo) Payout() int {
+	return int(float64(g.Wager) * hiLoRound(g.Multiplier))
+}
+
+// Guess Turns over the next card and returns 1 if the guess was right, 0 for a push, and -1 if it was wrong
+func (g *HiLo) Guess(higher bool) int {
+
+	multiplier := g.GuessMultiplier(higher)
+	current := g.Current().Rank
+
+	card := g.Deck.DealCard()
+	g.Cards = append(g.Cards, card)
+
+	switch {
+	case card.Rank == current:
+		return 0
+	case (card.Rank > current) == higher:
+		g.Multiplier *= multiplier
+		return 1
+	default:
+		return -1
+	}
+
+}
+
+// Content Returns the game message, with the cards turned over so far and the multiplier
+func (g *HiLo) Content() string {
+
+	var sb strings.Builder
+
+	sb.WriteString(T(g.Locale, "hilo.playing", g.Player.Username, g.Wager))
+
+	if len(g.Cards) > 1 {
+		sb.WriteString(T(g.Locale, "hilo.previous", RenderCards(g.Cards[:len(g.Cards)-1])))
+	}
+	sb.WriteString(T(g.Locale, "hilo.current", g.Current()))
+	sb.WriteString(T(g.Locale, "hilo.multiplier", hiLoRound(g.Multiplier)))
+
+	return sb.String()
+
+}
+
+// Components Returns the higher, lower and cash out buttons, or no buttons once the game is over.
+// A guess that can't win is disabled, and each guess shows what a right answer multiplies the wager by.
+func (g *HiLo) Components() []discordgo.MessageComponent {
+
+	if g.Over {
+		return []discordgo.MessageComponent{}
+	}
+
+	higher, lower := g.GuessMultiplier(true), g.GuessMultiplier(false)
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    T(g.Locale, "hilo.higher_button", hiLoRound(higher)),
+					Style:    discordgo.PrimaryButton,
+					CustomID: "hilo-higher",
+					Disabled: higher == 0,
+				},
+				discordgo.Button{
+					Label:    T(g.Locale, "hilo.lower_button", hiLoRound(lower)),
+					Style:    discordgo.PrimaryButton,
+					CustomID: "hilo-lower",
+					Disabled: lower == 0,
+				},
+				discordgo.Button{
+					Label:    T(g.Locale, "hilo.cash_out_button", g.Payout()),
+					Style:    discordgo.SuccessButton,
+					CustomID: "hilo-cashout",
+					Disabled: len(g.Cards) == 1,
+				},
+			},
+		},
+	}
+
+}
+
+// settle ends the game, paying the player their wager times the multiplier if they cashed out.
+// Returns a message with the result.
+func (g *HiLo) settle(cashedOut bool) string {
+
+	g.Over = true
+
+	hiLoGamesMu.Lock()
+	delete(HiLoGamesMap, g.Player.Username)
+	hiLoGamesMu.Unlock()
+
+	// Settling against the escrowed wager, so anything the player won or spent while they played is kept
+	if !cashedOut {
+		message, _ := dba.SettleEscrow(g.EscrowID, &g.Player, g.Locale, -g.Wager)
+		return T(g.Locale, "hilo.wrong", g.Player.Username) + message
+	}
+
+	payout := g.Payout()
+	message, _ := dba.SettleEscrow(g.EscrowID, &g.Player, g.Locale, payout-g.Wager)
+
+	return T(g.Locale, "hilo.cashed_out", g.Player.Username, hiLoRound(g.Multiplier), payout) + message
+
+}
+
+// hiLoRound Rounds a multiplier down to two decimals
+func hiLoRound(multiplier float64) float64 {
+	return math.Floor(multiplier*100) / 100
+}
+
+// HiLoCommand handles the /hilo command, turning over the first card
+func HiLoCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	wager := int(i.ApplicationCommandData().Options[0].IntValue())
+	locale := LocaleFor(i)
+	player := dba.FindPlayer(i.Member.User.Username)
+
+	if player.Chips < wager {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", player.Chips))
+		return
+	}
+
+	hiLoGamesMu.Lock()
+	if _, ok := HiLoGamesMap[player.Username]; ok {
+		hiLoGamesMu.Unlock()
+		RespondEphemeral(i, T(locale, "hilo.in_game"))
+		return
+	}
+	game := NewHiLo(player, wager)
+	game.InteractionID = i.ID
+	game.Locale = locale
+
+	// Holding the wager in escrow until the game is over, so it can be refunded if the bot stops mid game
+	escrowID, err := dba.EscrowChips(&game.Player, wager)
+	if err != nil {
+		hiLoGamesMu.Unlock()
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", dba.GetChipTotal(player.Username)))
+		return
+	}
+	game.EscrowID = escrowID
+	HiLoGamesMap[player.Username] = game
+	hiLoGamesMu.Unlock()
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    game.Content() + "\n\n" + T(game.Locale, "hilo.first_guess"),
+			Components: game.Components(),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// HiLoButton handles the higher, lower and cash out buttons
+func HiLoButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	hiLoGamesMu.Lock()
+	game, ok := HiLoGamesMap[i.Member.User.Username]
+	hiLoGamesMu.Unlock()
+
+	// Buttons pressed by other players, or on old games, are ignored
+	if !ok || i.Message.Interaction == nil || i.Message.Interaction.ID != game.InteractionID {
+		RespondEphemeral(i, T(LocaleFor(i), "hilo.not_yours"))
+		return
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if game.Over {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	var message string
+
+	switch i.MessageComponentData().CustomID {
+	case "hilo-cashout":
+		if len(game.Cards) == 1 {
+			RespondEphemeral(i, T(game.Locale, "hilo.guess_first"))
+			return
+		}
+		message = game.settle(true)
+	default:
+		higher := i.MessageComponentData().CustomID == "hilo-higher"
+		if game.GuessMultiplier(higher) == 0 {
+			RespondEphemeral(i, T(game.Locale, "hilo.cant_win"))
+			return
+		}
+
+		switch game.Guess(higher) {
+		case 1:
+			message = T(game.Locale, "hilo.right")
+		case 0:
+			message = T(game.Locale, "hilo.push")
+		default:
+			message = game.settle(false)
+		}
+
+		// Cashing out automatically once the deck runs out
+		if !game.Over && len(game.Deck) == 0 {
+			message = T(game.Locale, "hilo.last_card") + game.settle(true)
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    game.Content() + "\n\n" + message,
+			Components: game.Components(),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
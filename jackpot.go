@@ -0,0 +1,95 @@
+// This file handles the progressive jackpot. A percentage of every wager across the games goes into a shared pool,
+// which pays out when a player hits one of the jackpot triggers.
+package main
+
+import (
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Share of the jackpot paid out by each trigger
+const (
+	// JackpotBlackjackSuited is three sevens of the same suit in blackjack, which pays the whole pool
+	JackpotBlackjackSuited = 1.0
+	// JackpotBlackjackSevens is three sevens of any suit in blackjack
+	JackpotBlackjackSevens = 0.1
+	// JackpotSlots is the jackpot symbol across a whole payline on the slot machine
+	JackpotSlots = 1.0
+)
+
+// jackpotContribution Returns the player's net once the jackpot's share of the wager has come out of it, and the
+// share that goes into the pool. The pool is a real number, so it gets the configured percentage of every wager even
+// when that's a fraction of a chip, but only the whole chips of it come out of the player's net. The contribution comes
+// out of the wager, so a player who lost it has already paid, and anyone who got chips back pays out of those.
+func jackpotContribution(wager, net int) (int, float64) {
+
+	contribution := float64(wager) * Config.JackpotPercent / 100
+	if contribution <= 0 {
+		return net, 0
+	}
+
+	return max(net-int(contribution), -wager), contribution
+
+}
+
+// BlackjackJackpotShare Returns the share of the jackpot the hand wins, or 0 if it isn't a jackpot hand.
+// The hand must be exactly three sevens, and pays the whole pool if they are all the same suit.
+func BlackjackJackpotShare(hand BlackjackHand) float64 {
+
+	if len(hand) != 3 {
+		return 0
+	}
+
+	for _, card := range hand {
+		if card.Rank != Seven {
+			return 0
+		}
+	}
+
+	if hand[0].Suit == hand[1].Suit && hand[1].Suit == hand[2].Suit {
+		return JackpotBlackjackSuited
+	}
+
+	return JackpotBlackjackSevens
+
+}
+
+// PayJackpot pays the share of the jackpot to the player, announcing it in the jackpot channel if one is set.
+// reason is the key of the message saying what the player hit, formatted with reasonArgs.
+// The player is not updated in the database, so it can be saved with the rest of their game. Returns a message for the
+// player in their language.
+func PayJackpot(player *Player, locale string, share float64, reason string, reasonArgs ...any) string {
+
+	won := dba.ClaimJackpot(share, Config.JackpotSeed)
+	if won <= 0 {
+		return ""
+	}
+
+	player.Chips += won
+
+	if Config.JackpotChannelID != "" {
+		// The channel is for the whole server, so the announcement is in the default language
+		_, err := s.ChannelMessageSend(Config.JackpotChannelID, T(DefaultLocale, "jackpot.announce",
+			player.Username, T(DefaultLocale, reason, reasonArgs...), won,
+		))
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	return T(locale, "jackpot.won", T(locale, reason, reasonArgs...), won, player.Chips)
+
+}
+
+// JackpotCommand handles the /jackpot command, showing how many chips are in the pool
+func JackpotCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: T(LocaleFor(i), "jackpot.pool", int(dba.GetJackpot(Config.JackpotSeed))),
+		},
+	})
+
+}
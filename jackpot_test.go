@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestJackpotContribution(t *testing.T) {
+
+	defer func(config Configuration) { Config = config }(Config)
+	Config.JackpotPercent = 10
+
+	tests := []struct {
+		name             string
+		wager            int
+		net              int
+		wantNet          int
+		wantContribution float64
+	}{
+		{"lost wager has already paid", 100, -100, -100, 10},
+		{"comes out of winnings", 100, 100, 90, 10},
+		{"comes out of a push", 100, 0, -10, 10},
+		{"partly lost wager", 100, -95, -100, 10},
+		{"only whole chips come out of the net", 15, 15, 14, 1.5},
+		{"fraction of a chip still goes in the pool", 9, 9, 9, 0.9},
+	}
+
+	for _, test := range tests {
+		net, contribution := jackpotContribution(test.wager, test.net)
+		if net != test.wantNet || contribution != test.wantContribution {
+			t.Errorf("%s: got a net of %d and %g for the jackpot, want %d and %g", test.name, net, contribution, test.wantNet, test.wantContribution)
+		}
+	}
+
+}
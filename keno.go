@@ -0,0 +1,178 @@
+// This file implements keno. The player picks up to 10 numbers from 1 to 80, then 20 numbers are drawn, and the
+// player is paid by how many of their numbers were drawn.
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// KenoNumbers is the highest number on the keno board
+	KenoNumbers = 80
+	// KenoDrawn is how many numbers are drawn in each game
+	KenoDrawn = 20
+	// KenoMaxSpots is the most numbers a player can pick
+	KenoMaxSpots = 10
+)
+
+// KenoPaytable The chips paid for each chip wagered, including the wager itself, by how many numbers were picked
+// and then how many of them were drawn
+var KenoPaytable = map[int]map[int]int{
+	1:  {1: 3},
+	2:  {2: 12},
+	3:  {2: 1, 3: 42},
+	4:  {2: 1, 3: 4, 4: 100},
+	5:  {3: 1, 4: 20, 5: 450},
+	6:  {3: 1, 4: 4, 5: 90, 6: 1500},
+	7:  {3: 1, 4: 2, 5: 15, 6: 400, 7: 5000},
+	8:  {4: 2, 5: 10, 6: 50, 7: 1000, 8: 15000},
+	9:  {4: 1, 5: 5, 6: 25, 7: 200, 8: 4000, 9: 40000},
+	10: {5: 2, 6: 20, 7: 80, 8: 500, 9: 5000, 10: 100000},
+}
+
+// ParseKenoPicks Reads the player's numbers, separated by spaces or commas. Each must be from 1 to 80, with no repeats.
+func ParseKenoPicks(input string) ([]int, error) {
+
+	var picks []int
+
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' }) {
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 1 || number > KenoNumbers {
+			return nil, NewLocalizedError("keno.error.number", field, KenoNumbers)
+		}
+		if slices.Contains(picks, number) {
+			return nil, NewLocalizedError("keno.error.repeat", number)
+		}
+		picks = append(picks, number)
+	}
+
+	if len(picks) == 0 || len(picks) > KenoMaxSpots {
+		return nil, NewLocalizedError("keno.error.spots", KenoMaxSpots)
+	}
+
+	slices.Sort(picks)
+
+	return picks, nil
+
+}
+
+// DrawKenoNumbers Draws count different numbers from 1 to 80, in the order they were drawn
+func DrawKenoNumbers(count int) []int {
+
+	numbers := make([]int, KenoNumbers)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+
+	// Shuffling just the numbers that are drawn
+	for i := 0; i < count; i++ {
+		j := i + RNG.Intn(len(numbers)-i)
+		numbers[i], numbers[j] = numbers[j], numbers[i]
+	}
+
+	return numbers[:count]
+
+}
+
+// KenoCatches Returns the picked numbers that were drawn
+func KenoCatches(picks []int, drawn []int) []int {
+	var catches []int
+	for _, pick := range picks {
+		if slices.Contains(drawn, pick) {
+			catches = append(catches, pick)
+		}
+	}
+	return catches
+}
+
+// KenoCommand handles the /keno command, drawing the numbers and paying the player for the ones they caught
+func KenoCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	// Getting options and storing in map
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	wager := int(optionMap["wager"].IntValue())
+	locale := LocaleFor(i)
+
+	// Using the player's numbers, or picking them at random for a quick pick
+	var picks []int
+	if opt, ok := optionMap["numbers"]; ok {
+		var err error
+		if picks, err = ParseKenoPicks(opt.StringValue()); err != nil {
+			RespondEphemeral(i, T(locale, "keno.bad_numbers", ErrorIn(locale, err)))
+			return
+		}
+	} else {
+		spots := KenoMaxSpots
+		if opt, ok := optionMap["quick_pick"]; ok {
+			spots = int(opt.IntValue())
+		}
+		picks = DrawKenoNumbers(spots)
+		slices.Sort(picks)
+	}
+
+	player := dba.FindPlayer(i.Member.User.Username)
+	if player.Chips < wager {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", player.Chips))
+		return
+	}
+
+	// Holding the wager in escrow while the numbers are drawn, so the draw is settled against the wager rather than
+	// the chips the player had when the command came in
+	escrowID, err := dba.EscrowChips(&player, wager)
+	if err != nil {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", dba.GetChipTotal(player.Username)))
+		return
+	}
+
+	drawn := DrawKenoNumbers(KenoDrawn)
+	catches := KenoCatches(picks, drawn)
+	pays := KenoPaytable[len(picks)][len(catches)]
+
+	var sb strings.Builder
+	sb.WriteString(T(locale, "keno.bets", player.Username, wager))
+	sb.WriteString(T(locale, "keno.numbers", joinInts(picks)))
+	slices.Sort(drawn)
+	sb.WriteString(T(locale, "keno.drawn", joinInts(drawn)))
+	sb.WriteString(T(locale, "keno.caught", len(catches), len(picks)))
+	if len(catches) > 0 {
+		sb.WriteString(fmt.Sprintf(" (%s)", joinInts(catches)))
+	}
+	if pays > 0 {
+		sb.WriteString(T(locale, "keno.pays", pays*wager))
+	} else {
+		sb.WriteString(".")
+	}
+	settled, _ := dba.SettleEscrow(escrowID, &player, locale, pays*wager-wager)
+	sb.WriteString(settled)
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: sb.String(),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// joinInts Returns the numbers separated by commas
+func joinInts(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, number := range numbers {
+		parts[i] = strconv.Itoa(number)
+	}
+	return strings.Join(parts, ", ")
+}
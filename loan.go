@@ -0,0 +1,231 @@
+// This file handles loans from the house. Players can borrow up to a credit limit that grows with the games they've
+// played and the loans they've paid back, and shrinks with each loan they defaulted on. Interest compounds daily
+// until the loan is repaid, and part of each blackjack win goes to paying it off. A loan that isn't repaid by the
+// end of its term is in default, which shows on the player's profile and counts against their credit.
+package main
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Loan A loan from the house. Balance is what was owed at AccruedAt, and interest is added from then on.
+type Loan struct {
+	ID        int
+	Username  string
+	Principal int
+	Balance   float64
+	TakenAt   time.Time
+	AccruedAt time.Time
+	DueAt     time.Time
+}
+
+// LoanHistory The loans a player has finished with, for working out their credit limit
+type LoanHistory struct {
+	// Repaid is the number of loans paid off by their due date
+	Repaid int
+	// Defaulted is the number of loans that weren't paid off by their due date, including one still owed
+	Defaulted int
+}
+
+// OwedAt Returns what is owed on the loan at the time, with the daily interest compounded since it was last paid
+func (l Loan) OwedAt(now time.Time) float64 {
+
+	days := now.Sub(l.AccruedAt).Hours() / 24
+	if days <= 0 {
+		return l.Balance
+	}
+
+	return l.Balance * math.Pow(1+Config.LoanInterestPercent/100, days)
+
+}
+
+// Owed Returns what is owed on the loan at the time, rounded up to the chip
+func (l Loan) Owed(now time.Time) int {
+	// Compounding leaves a little floating point error, so a whole number of chips like 121 can come out as
+	// 121.00000000000001. That shouldn't be rounded up to another chip.
+	return int(math.Ceil(l.OwedAt(now) - 1e-9))
+}
+
+// InDefault Returns true if the loan is past its due date
+func (l Loan) InDefault(now time.Time) bool {
+	return now.After(l.DueAt)
+}
+
+// CreditLimit Returns the most chips the player can borrow. It starts at the base limit, goes up for every game
+// played and loan repaid, and down for every loan defaulted on, up to the configured maximum.
+func CreditLimit(player Player, history LoanHistory) int {
+
+	games := player.Wins + player.Ties + player.Losses
+	limit := Config.LoanBaseLimit + games*Config.LoanLimitPerGame + (history.Repaid-history.Defaulted)*Config.LoanBaseLimit
+
+	return max(0, min(limit, Config.LoanMaxLimit))
+
+}
+
+// RepayFromWinnings Puts the configured share of the player's winnings towards their loan, if they have one.
+// The player passed in is updated too, and should already be saved. Returns a message for the player.
+func RepayFromWinnings(player *Player, locale string, winnings int) string {
+
+	if winnings <= 0 {
+		return ""
+	}
+
+	loan, ok := dba.GetLoan(player.Username)
+	if !ok {
+		return ""
+	}
+
+	now := time.Now()
+	share := max(1, winnings*Config.LoanRepayPercent/100)
+
+	paid, ok := dba.RepayLoan(player, loan, share, now)
+	if !ok || paid == 0 {
+		return ""
+	}
+
+	if left := loan.Owed(now) - paid; left > 0 {
+		return T(locale, "loan.auto_repaid", paid, left)
+	}
+
+	return T(locale, "loan.auto_repaid_all", paid)
+
+}
+
+// LoanCommand handles the /loan command, for borrowing chips, paying them back and seeing what's owed
+func LoanCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	locale := LocaleFor(i)
+	player := dba.FindPlayer(i.Member.User.Username)
+	loan, hasLoan := dba.GetLoan(player.Username)
+	now := time.Now()
+
+	var message string
+
+	switch subcommand.Name {
+	case "take":
+		amount := int(subcommand.Options[0].IntValue())
+
+		if hasLoan {
+			if loan.InDefault(now) {
+				RespondEphemeral(i, T(locale, "loan.defaulted"))
+			} else {
+				RespondEphemeral(i, T(locale, "loan.outstanding"))
+			}
+			return
+		}
+
+		limit := CreditLimit(player, dba.GetLoanHistory(player.Username, now))
+		if amount > limit {
+			RespondEphemeral(i, T(locale, "loan.over_limit", limit))
+			return
+		}
+
+		due := now.Add(time.Duration(Config.LoanTermDays) * 24 * time.Hour)
+		if !dba.TakeLoan(&player, amount, now, due) {
+			RespondEphemeral(i, T(locale, "loan.outstanding"))
+			return
+		}
+
+		message = T(locale, "loan.taken", amount, Config.LoanInterestPercent, due.Unix()) + T(locale, "net.total", player.Chips)
+
+	case "repay":
+		if !hasLoan {
+			RespondEphemeral(i, T(locale, "loan.none"))
+			return
+		}
+
+		// Paying off the whole loan unless an amount is given
+		owed := loan.Owed(now)
+		amount := owed
+		if len(subcommand.Options) > 0 {
+			amount = int(subcommand.Options[0].IntValue())
+		}
+		amount = min(amount, owed, player.Chips)
+
+		paid, ok := dba.RepayLoan(&player, loan, amount, now)
+		if !ok || paid == 0 {
+			RespondEphemeral(i, T(locale, "game.not_enough_chips", player.Chips))
+			return
+		}
+
+		if paid < owed {
+			message = T(locale, "loan.repaid", paid, owed-paid)
+		} else {
+			message = T(locale, "loan.repaid_all", paid)
+		}
+		message += T(locale, "net.total", player.Chips)
+
+	default:
+		message = LoanStatus(locale, player, now)
+	}
+
+	RespondEphemeral(i, message)
+
+}
+
+// LoanStatus Returns what the player owes and when it's due, and how much they can borrow
+func LoanStatus(locale string, player Player, now time.Time) string {
+
+	loan, ok := dba.GetLoan(player.Username)
+	if !ok {
+		return T(locale, "loan.none") + T(locale, "loan.credit", CreditLimit(player, dba.GetLoanHistory(player.Username, now)))
+	}
+
+	if loan.InDefault(now) {
+		return T(locale, "loan.status_defaulted", loan.Owed(now), loan.DueAt.Unix())
+	}
+
+	return T(locale, "loan.status", loan.Owed(now), loan.DueAt.Unix(), Config.LoanInterestPercent)
+
+}
+
+// ProfileCommand handles the /profile command, showing a player's chips, record and loans
+func ProfileCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	locale := LocaleFor(i)
+
+	username := i.Member.User.Username
+	if options := i.ApplicationCommandData().Options; len(options) > 0 {
+		username = options[0].UserValue(s).Username
+	}
+
+	player := dba.FindPlayer(username)
+	now := time.Now()
+	history := dba.GetLoanHistory(username, now)
+
+	loanValue := T(locale, "profile.no_loan")
+	if loan, ok := dba.GetLoan(username); ok {
+		if loan.InDefault(now) {
+			loanValue = T(locale, "profile.loan_defaulted", loan.Owed(now), loan.DueAt.Unix())
+		} else {
+			loanValue = T(locale, "profile.loan", loan.Owed(now), loan.DueAt.Unix())
+		}
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: T(locale, "profile.title", player.Username),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: T(locale, "blackjack.balance"), Value: T(locale, "profile.chips", player.Chips), Inline: true},
+			{Name: T(locale, "profile.record"), Value: T(locale, "profile.record_value", player.Wins, player.Ties, player.Losses), Inline: true},
+			{Name: T(locale, "profile.loan_title"), Value: loanValue},
+			{Name: T(locale, "profile.credit"), Value: T(locale, "profile.chips", CreditLimit(player, history)), Inline: true},
+			{Name: T(locale, "profile.defaults"), Value: T(locale, "profile.defaults_value", history.Repaid, history.Defaulted), Inline: true},
+		},
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
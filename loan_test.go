@@ -0,0 +1,74 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestLoanOwedAt(t *testing.T) {
+
+	defer func(config Configuration) { Config = config }(Config)
+	Config.LoanInterestPercent = 10
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		balance   float64
+		accrued   time.Duration
+		wantOwed  float64
+		wantChips int
+	}{
+		{"just taken", 100, 0, 100, 100},
+		{"clock behind the last payment", 100, -time.Hour, 100, 100},
+		{"one day", 100, day, 110, 110},
+		{"two days compound", 100, 2 * day, 121, 121},
+		{"half a day", 100, day / 2, 100 * math.Sqrt(1.1), 105},
+		{"a day and a half", 100, day + day/2, 110 * math.Sqrt(1.1), 116},
+		{"partly repaid only accrues on what's left", 50, day, 55, 55},
+		{"fully repaid", 0, 3 * day, 0, 0},
+	}
+
+	for _, test := range tests {
+		loan := Loan{Principal: 100, Balance: test.balance, TakenAt: now.Add(-5 * day), AccruedAt: now.Add(-test.accrued), DueAt: now.Add(2 * day)}
+		if got := loan.OwedAt(now); math.Abs(got-test.wantOwed) > 1e-9 {
+			t.Errorf("%s: got %f owed, want %f", test.name, got, test.wantOwed)
+		}
+		if got := loan.Owed(now); got != test.wantChips {
+			t.Errorf("%s: got %d chips owed, want %d", test.name, got, test.wantChips)
+		}
+	}
+
+}
+
+func TestCreditLimit(t *testing.T) {
+
+	defer func(config Configuration) { Config = config }(Config)
+	Config.LoanBaseLimit = 100
+	Config.LoanLimitPerGame = 2
+	Config.LoanMaxLimit = 2000
+
+	tests := []struct {
+		name    string
+		player  Player
+		history LoanHistory
+		want    int
+	}{
+		{"new player", Player{}, LoanHistory{}, 100},
+		{"games played", Player{Wins: 5, Ties: 2, Losses: 3}, LoanHistory{}, 120},
+		{"loans repaid", Player{}, LoanHistory{Repaid: 2}, 300},
+		{"a default takes off a repaid loan", Player{}, LoanHistory{Repaid: 2, Defaulted: 1}, 200},
+		{"defaults can't take it below 0", Player{Wins: 10}, LoanHistory{Defaulted: 3}, 0},
+		{"exactly at the maximum", Player{Losses: 950}, LoanHistory{}, 2000},
+		{"capped at the maximum", Player{Wins: 5000}, LoanHistory{Repaid: 10}, 2000},
+	}
+
+	for _, test := range tests {
+		if got := CreditLimit(test.player, test.history); got != test.want {
+			t.Errorf("%s: got a limit of %d, want %d", test.name, got, test.want)
+		}
+	}
+
+}
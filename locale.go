@@ -0,0 +1,255 @@
+// This file handles translating the bot's messages. Each language has a catalog of messages by key, and the language
+// used for an interaction is the player's own setting, then their server's setting, then the language their Discord
+// client is in, falling back to English. The catalogs are checked against each other when the bot starts.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// DefaultLocale is the language used when no other language is set or supported. Its catalog has every key.
+const DefaultLocale = "en"
+
+// Message A message in a catalog. Messages that count something have a One form, used when the count is one,
+// and the Other form is used for every other count.
+type Message struct {
+	One   string
+	Other string
+}
+
+// Catalog The messages for one language, by key
+type Catalog map[string]Message
+
+// Catalogs every supported language's catalog, by language code
+var Catalogs = map[string]Catalog{
+	"en": EnglishCatalog,
+	"es": SpanishCatalog,
+}
+
+// Locale scopes, for the language a player picks for themselves or a server admin picks for the server
+const (
+	LocaleScopeUser  = "user"
+	LocaleScopeGuild = "guild"
+)
+
+// formatVerb matches the fmt verbs in a message, so the catalogs can be checked for the same arguments.
+// A literal percent sign, %%, is matched too so it isn't mistaken for the start of a verb. Indexed verbs like %[2]s
+// are matched with the index captured, so translations can put the arguments in a different order.
+var formatVerb = regexp.MustCompile(`%%|%[-+# 0]*[0-9]*(?:\.[0-9]+)?(?:\[([0-9]+)\])?[a-zA-Z]`)
+
+// formatArgs Returns the verb used for each argument of a message, in the order of the arguments rather than the order
+// they appear in. Following fmt, a verb without an index uses the argument after the one before it.
+func formatArgs(message string) []string {
+
+	var args []string
+	next := 0
+
+	for _, match := range formatVerb.FindAllStringSubmatch(message, -1) {
+		if match[0] == "%%" {
+			continue
+		}
+
+		verb := match[0]
+		if match[1] != "" {
+			index, _ := strconv.Atoi(match[1])
+			next = index - 1
+			verb = strings.Replace(verb, "["+match[1]+"]", "", 1)
+		}
+		if next < 0 {
+			continue
+		}
+
+		for len(args) <= next {
+			args = append(args, "")
+		}
+		args[next] = verb
+		next++
+	}
+
+	return args
+
+}
+
+// SupportedLocale Returns the supported language for a Discord locale, e.g. "es" for "es-ES", or an empty string if
+// the language isn't supported
+func SupportedLocale(locale string) string {
+	language, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	if _, ok := Catalogs[language]; ok {
+		return language
+	}
+	return ""
+}
+
+// LocaleFor Returns the language to reply to the interaction in. The player's own setting comes first, then their
+// server's, then the language of their Discord client, then the server's Discord language.
+func LocaleFor(i *discordgo.InteractionCreate) string {
+
+	userLocale, guildLocale := dba.GetLocales(i.Member.User.Username, i.GuildID)
+
+	candidates := []string{userLocale, guildLocale, string(i.Locale)}
+	if i.GuildLocale != nil {
+		candidates = append(candidates, string(*i.GuildLocale))
+	}
+
+	for _, candidate := range candidates {
+		if locale := SupportedLocale(candidate); locale != "" {
+			return locale
+		}
+	}
+
+	return DefaultLocale
+
+}
+
+// lookup Returns the message for the key in the language, falling back to English if the language doesn't have it
+func lookup(locale string, key string) Message {
+
+	if message, ok := Catalogs[locale][key]; ok {
+		return message
+	}
+	if message, ok := Catalogs[DefaultLocale][key]; ok {
+		return message
+	}
+
+	// Showing the key, so a missing message is noticed rather than sending an empty one
+	log.Printf("No message for %q", key)
+	return Message{Other: key}
+
+}
+
+// T Returns the message for the key in the language, formatted with the arguments
+func T(locale string, key string, args ...any) string {
+	return fmt.Sprintf(lookup(locale, key).Other, args...)
+}
+
+// TN Returns the message for the key in the language in the form for the count, formatted with the arguments.
+// English and Spanish both use the One form for exactly one, and the Other form for everything else.
+func TN(locale string, key string, count int, args ...any) string {
+
+	message := lookup(locale, key)
+
+	format := message.Other
+	if count == 1 && message.One != "" {
+		format = message.One
+	}
+
+	return fmt.Sprintf(format, args...)
+
+}
+
+// LocalizedError An error shown to players, which is translated with ErrorIn. Its Error method gives the English
+// message, for logs.
+type LocalizedError struct {
+	Key  string
+	Args []any
+}
+
+// NewLocalizedError Returns an error with the message for the key, formatted with the arguments
+func NewLocalizedError(key string, args ...any) error {
+	return &LocalizedError{Key: key, Args: args}
+}
+
+func (e *LocalizedError) Error() string {
+	return T(DefaultLocale, e.Key, e.Args...)
+}
+
+// ErrorIn Returns the error's message in the language. Errors that aren't a LocalizedError can't be translated, so
+// their message is returned as it is.
+func ErrorIn(locale string, err error) string {
+
+	var localized *LocalizedError
+	if errors.As(err, &localized) {
+		return T(locale, localized.Key, localized.Args...)
+	}
+
+	return err.Error()
+
+}
+
+// CheckCatalogs Returns an error listing every message that is missing from a catalog, is missing its One form where
+// English has one, or takes different arguments than the English message
+func CheckCatalogs() error {
+
+	var problems []string
+
+	english := Catalogs[DefaultLocale]
+
+	for language, catalog := range Catalogs {
+		for key, want := range english {
+			got, ok := catalog[key]
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("%s is missing %q", language, key))
+				continue
+			case want.One != "" && got.One == "":
+				problems = append(problems, fmt.Sprintf("%s is missing the singular form of %q", language, key))
+			}
+
+			forms := []string{got.Other}
+			if got.One != "" {
+				forms = append(forms, got.One)
+			}
+			for _, form := range forms {
+				if !slices.Equal(formatArgs(form), formatArgs(want.Other)) {
+					problems = append(problems, fmt.Sprintf("%s has different arguments for %q", language, key))
+				}
+			}
+		}
+
+		for key := range catalog {
+			if _, ok := english[key]; !ok {
+				problems = append(problems, fmt.Sprintf("%s has %q, which isn't in English", language, key))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		slices.Sort(problems)
+		return fmt.Errorf("the message catalogs don't match:\n%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
+
+}
+
+// LanguageCommand handles the /language command, for setting the language for the player or the whole server
+func LanguageCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	language := subcommand.Options[0].StringValue()
+
+	// Anything other than a supported language clears the setting
+	locale := SupportedLocale(language)
+
+	var message string
+
+	switch subcommand.Name {
+	case "server":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			RespondEphemeral(i, T(LocaleFor(i), "language.no_permission"))
+			return
+		}
+		dba.SetLocale(LocaleScopeGuild, i.GuildID, locale)
+		message = T(LocaleFor(i), "language.guild_reset")
+		if locale != "" {
+			message = T(locale, "language.guild_set", T(locale, "language.name"))
+		}
+	default:
+		dba.SetLocale(LocaleScopeUser, i.Member.User.Username, locale)
+		message = T(LocaleFor(i), "language.user_reset")
+		if locale != "" {
+			message = T(locale, "language.user_set", T(locale, "language.name"))
+		}
+	}
+
+	RespondEphemeral(i, message)
+
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCheckCatalogs(t *testing.T) {
+	if err := CheckCatalogs(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFormatArgs(t *testing.T) {
+
+	tests := []struct {
+		message string
+		want    []string
+	}{
+		{"no verbs", nil},
+		{"%s has %d chips", []string{"%s", "%d"}},
+		{"100%% of %d chips", []string{"%d"}},
+		{"%.2fx and %+d", []string{"%.2f", "%+d"}},
+		{"%[2]d chips for %[1]s", []string{"%s", "%d"}},
+		{"%[2]d then %s", []string{"", "%d", "%s"}},
+		{"%s, %[1]s again", []string{"%s"}},
+	}
+
+	for _, test := range tests {
+		if got := formatArgs(test.message); !slices.Equal(got, test.want) {
+			t.Errorf("formatArgs(%q) = %q, want %q", test.message, got, test.want)
+		}
+	}
+
+}
@@ -0,0 +1,169 @@
+// This file implements the lottery. Members buy tickets throughout the day, and once a day a draw picks one ticket to
+// win the whole pot. Draws are scheduled in the database, so a draw that was due while the bot was offline is made as
+// soon as it starts again.
+package main
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// LotterySchedulerTick is the longest the scheduler waits before checking the next draw again
+const LotterySchedulerTick = time.Minute
+
+// ErrLotteryDrawn is returned when tickets are bought for a draw that has already been drawn
+var ErrLotteryDrawn = errors.New("lottery already drawn")
+
+// LotteryDraw A scheduled lottery draw
+type LotteryDraw struct {
+	ID      int
+	DrawsAt time.Time
+}
+
+// LotteryTickets The tickets a player has in a draw, and the chips they spent on them
+type LotteryTickets struct {
+	Username string
+	Tickets  int
+	Chips    int
+}
+
+// NextLotteryDrawTime Returns the next time a draw should be made after the given time, at the configured hour in UTC
+func NextLotteryDrawTime(after time.Time) time.Time {
+
+	after = after.UTC()
+	next := time.Date(after.Year(), after.Month(), after.Day(), Config.LotteryDrawHour, 0, 0, 0, time.UTC)
+	if !next.After(after) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+
+}
+
+// PickLotteryWinner Picks the winning ticket, so each player's chance of winning is their share of the tickets.
+// Returns the winner's username, or an empty string if nobody bought a ticket.
+func PickLotteryWinner(tickets []LotteryTickets) string {
+
+	total := 0
+	for _, entry := range tickets {
+		total += entry.Tickets
+	}
+	if total == 0 {
+		return ""
+	}
+
+	winning := RNG.Intn(total)
+	for _, entry := range tickets {
+		if winning < entry.Tickets {
+			return entry.Username
+		}
+		winning -= entry.Tickets
+	}
+
+	return ""
+
+}
+
+// RunLotteryScheduler makes each draw when it is due, and schedules the next one. It never returns, so it should be
+// run in its own goroutine.
+func RunLotteryScheduler() {
+
+	for {
+		draw := dba.GetLotteryDraw(NextLotteryDrawTime(time.Now()))
+
+		if wait := time.Until(draw.DrawsAt); wait > 0 {
+			time.Sleep(min(wait, LotterySchedulerTick))
+			continue
+		}
+
+		RunLotteryDraw(draw)
+	}
+
+}
+
+// RunLotteryDraw picks the winner of the draw, pays them the pot and announces it in the lottery channel
+func RunLotteryDraw(draw LotteryDraw) {
+
+	winner, pot, drawn := dba.FinishLotteryDraw(draw.ID, PickLotteryWinner)
+	if !drawn {
+		return
+	}
+
+	if Config.LotteryChannelID == "" {
+		return
+	}
+
+	// The channel is for the whole server, so the announcement is in the default language
+	message := T(DefaultLocale, "lottery.no_tickets")
+	if winner != "" {
+		message = T(DefaultLocale, "lottery.drawn", winner, pot)
+	}
+	message += T(DefaultLocale, "lottery.next_draw", NextLotteryDrawTime(time.Now()).Unix())
+
+	if _, err := s.ChannelMessageSend(Config.LotteryChannelID, message); err != nil {
+		log.Println(err)
+	}
+
+}
+
+// LotteryCommand handles the /lottery command, for buying tickets and seeing the next draw
+func LotteryCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	locale := LocaleFor(i)
+	player := dba.FindPlayer(i.Member.User.Username)
+	draw := dba.GetLotteryDraw(NextLotteryDrawTime(time.Now()))
+
+	var message string
+
+	switch subcommand.Name {
+	case "buy":
+		tickets := int(subcommand.Options[0].IntValue())
+		cost := tickets * Config.LotteryTicketPrice
+
+		if player.Chips < cost {
+			RespondEphemeral(i, TN(locale, "lottery.not_enough_chips", tickets, tickets, cost, player.Chips))
+			return
+		}
+
+		if err := dba.BuyLotteryTickets(&player, draw.ID, tickets, cost); errors.Is(err, ErrNotEnoughChips) {
+			RespondEphemeral(i, TN(locale, "lottery.not_enough_chips", tickets, tickets, cost, dba.GetChipTotal(player.Username)))
+			return
+		} else if err != nil {
+			RespondEphemeral(i, T(locale, "lottery.drawing"))
+			return
+		}
+		message = TN(locale, "lottery.bought", tickets, player.Username, tickets, cost, player.Chips)
+	}
+
+	// Showing the pot and the player's chances
+	entries := dba.GetLotteryTickets(draw.ID)
+	pot, total, mine := 0, 0, 0
+	for _, entry := range entries {
+		pot += entry.Chips
+		total += entry.Tickets
+		if entry.Username == player.Username {
+			mine = entry.Tickets
+		}
+	}
+
+	message += T(locale, "lottery.status", draw.DrawsAt.Unix(), Config.LotteryTicketPrice)
+	message += TN(locale, "lottery.pot", total, pot, total)
+	if mine > 0 {
+		message += TN(locale, "lottery.mine", mine, mine, float64(mine)/float64(total)*100)
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: message,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
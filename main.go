@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"github.com/bwmarrin/discordgo"
 	"github.com/rodaine/table"
@@ -19,26 +20,81 @@ var (
 	// The database adapter
 	dba    DBA
 	Config Configuration
+
+	// slotsReport is set by the -slots-rtp flag, to print the slot machine's payout math and exit without going online
+	slotsReport = flag.Bool("slots-rtp", false, "print the slot machine's RTP and exit")
 )
 
-func init() {
-	Config = GetConfig()
+// startup Checks the message catalogs, opens the database, loads the slot machine and creates the Discord session.
+// It's only run when the bot is going online, so nothing else touches the database.
+func startup() {
+
+	// Making sure every language has every message before anything is sent
+	if err := CheckCatalogs(); err != nil {
+		log.Fatal(err)
+	}
+
 	// Opening the database connection
 	dba.OpenConnection(Config.DbPath)
 
+	// Giving back any stakes left in escrow by games between players that were running when the bot stopped
+	if refunded := dba.RefundAllEscrow(); refunded > 0 {
+		log.Printf("Refunded %d stakes left in escrow", refunded)
+	}
+
+	// Loading the slot machine. If it can't be loaded the rest of the bot still works, just without slots.
 	var err error
+	Slots, err = LoadSlotsConfig(Config.SlotsPath)
+	if err != nil {
+		log.Printf("Slots are disabled, couldn't load %s: %v", Config.SlotsPath, err)
+		Slots.Enabled = false
+	} else if Slots.Enabled {
+		log.Printf("Slots are enabled: %s", Slots.Report())
+	}
+
 	// Creating a new Discord session using the bot token
 	s, err = discordgo.New("Bot " + Config.Token)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	addInteractionHandler()
+
 }
 
 // Adding discord slash commands and handlers
 var (
 	minWager = 1.0
 
+	// Limits for the card counting trainer options
+	minDecks       = 1.0
+	maxDecks       = 8.0
+	minDealSeconds = 1.0
+	maxDealSeconds = 10.0
+	minCheckCards  = 1.0
+	maxCheckCards  = 52.0
+
+	// Limits for the number of mines hidden in a game of mines
+	minMines = 1.0
+	maxMines = 24.0
+
+	// Limits for the number of spots picked in keno, and lottery tickets bought at once
+	minKenoSpots = 1.0
+	maxKenoSpots = float64(KenoMaxSpots)
+	minTickets   = 1.0
+	maxTickets   = 100.0
+
+	// Limits for how long a roulette table's betting window is open
+	minBettingSeconds = 15.0
+	maxBettingSeconds = 300.0
+
+	// languageChoices the languages that can be picked with /language, plus going back to the default
+	languageChoices = []*discordgo.ApplicationCommandOptionChoice{
+		{Name: "English", Value: "en"},
+		{Name: "Español", Value: "es"},
+		{Name: "Discord default", Value: "auto"},
+	}
+
 	// BlackjackGamesMap Global variable slice of ongoing games of blackjack
 	BlackjackGamesMap = make(map[string]*Blackjack)
 
@@ -91,6 +147,520 @@ var (
 				},
 			},
 		},
+		{
+			Name:        "blackjack-trainer",
+			Description: "Practice blackjack with no chips at stake, and get told when you don't follow basic strategy.",
+		},
+		{
+			Name:        "trainer-stats",
+			Description: "See how accurately you've followed basic strategy and counted cards in the trainers.",
+		},
+		{
+			Name:        "count-trainer",
+			Description: "Practice Hi-Lo card counting. Cards are dealt into a thread and you're asked for the count.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "decks",
+					Description: "The number of decks in the shoe.",
+					Required:    false,
+					MinValue:    &minDecks,
+					MaxValue:    maxDecks,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionNumber,
+					Name:        "seconds",
+					Description: "The number of seconds between each card being dealt.",
+					Required:    false,
+					MinValue:    &minDealSeconds,
+					MaxValue:    maxDealSeconds,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "cards",
+					Description: "The number of cards dealt before you're asked for the count.",
+					Required:    false,
+					MinValue:    &minCheckCards,
+					MaxValue:    maxCheckCards,
+				},
+			},
+		},
+		{
+			Name:        "roulette",
+			Description: "Spin the roulette wheel! Place several bets at once by separating them with commas.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "bets",
+					Description: "Your bets, e.g. \"red 10, straight 17 5, split 17-20 5, corner 1-2-4-5 5, dozen 2 10\"",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "wheel",
+					Description: "European has a single zero, American has 0 and 00. European by default.",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "european",
+							Value: EuropeanWheel,
+						},
+						{
+							Name:  "american",
+							Value: AmericanWheel,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "roulette-table",
+			Description: "Open a roulette table in this channel. Anyone can bet until the betting window closes.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "wheel",
+					Description: "European has a single zero, American has 0 and 00. European by default.",
+					Required:    false,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "european",
+							Value: EuropeanWheel,
+						},
+						{
+							Name:  "american",
+							Value: AmericanWheel,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "seconds",
+					Description: "How many seconds betting is open for. 60 by default.",
+					Required:    false,
+					MinValue:    &minBettingSeconds,
+					MaxValue:    maxBettingSeconds,
+				},
+			},
+		},
+		{
+			Name:        "slots",
+			Description: "Pull the lever on the slot machine!",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "wager",
+					Description: "The amount of chips you want to bet on each payline.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+			},
+		},
+		{
+			Name:        "jackpot",
+			Description: "See how many chips are in the progressive jackpot.",
+		},
+		{
+			Name:        "poker",
+			Description: "Open a Texas Hold'em table to play against other members.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "buyin",
+					Description: "The chips each player brings to the table.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "small_blind",
+					Description: "The small blind. The big blind is twice this. 1 by default.",
+					Required:    false,
+					MinValue:    &minWager,
+				},
+			},
+		},
+		{
+			Name:        "videopoker",
+			Description: "Play a hand of Jacks or Better video poker!",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "wager",
+					Description: "The amount of chips you want to bet.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+			},
+		},
+		{
+			Name:        "baccarat",
+			Description: "Bet on the Player hand, the Banker hand or a tie in baccarat!",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "bet",
+					Description: "Player pays 1:1, Banker pays 1:1 less 5% commission, and a tie pays 8:1.",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "player",
+							Value: BaccaratPlayer,
+						},
+						{
+							Name:  "banker",
+							Value: BaccaratBanker,
+						},
+						{
+							Name:  "tie",
+							Value: BaccaratTie,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "wager",
+					Description: "The amount of chips you want to bet.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+			},
+		},
+		{
+			Name:        "craps",
+			Description: "Play craps at this channel's table.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "bet",
+					Description: "Put a bet on the craps table.",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "type",
+							Description: "The type of bet.",
+							Required:    true,
+							Choices: []*discordgo.ApplicationCommandOptionChoice{
+								{
+									Name:  "pass line",
+									Value: CrapsPass,
+								},
+								{
+									Name:  "don't pass",
+									Value: CrapsDontPass,
+								},
+								{
+									Name:  "come",
+									Value: CrapsCome,
+								},
+								{
+									Name:  "don't come",
+									Value: CrapsDontCome,
+								},
+								{
+									Name:  "odds",
+									Value: CrapsOdds,
+								},
+								{
+									Name:  "place",
+									Value: CrapsPlace,
+								},
+								{
+									Name:  "field",
+									Value: CrapsField,
+								},
+							},
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "amount",
+							Description: "The amount of chips you want to bet.",
+							Required:    true,
+							MinValue:    &minWager,
+						},
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "number",
+							Description: "The number for a place bet, or the come bet to add odds to. Leave out for odds on the line.",
+							Required:    false,
+							Choices:     crapsNumberChoices(),
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "roll",
+					Description: "Roll the dice, if you're the shooter.",
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "table",
+					Description: "See the point, the shooter and the bets on the table.",
+				},
+			},
+		},
+		{
+			Name:        "duel",
+			Description: "Challenge another member to a coin flip or dice roll. The winner takes the pot!",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "opponent",
+					Description: "The member you want to challenge.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "game",
+					Description: "A coin flip, two dice each with the highest total winning, or a hand of blackjack each.",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{
+							Name:  "coin flip",
+							Value: DuelCoin,
+						},
+						{
+							Name:  "dice",
+							Value: DuelDice,
+						},
+						{
+							Name:  "blackjack",
+							Value: DuelBlackjack,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "stake",
+					Description: "The chips each of you puts in the pot.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+			},
+		},
+		{
+			Name:        "crash",
+			Description: "Bet on the next round of crash. Cash out before the multiplier crashes!",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "wager",
+					Description: "The amount of chips you want to bet.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+			},
+		},
+		{
+			Name:        "mines",
+			Description: "Reveal tiles without hitting a mine. Each safe tile raises your multiplier!",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "wager",
+					Description: "The amount of chips you want to bet.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "mines",
+					Description: "How many mines are hidden on the 5x5 grid. More mines means bigger multipliers.",
+					Required:    true,
+					MinValue:    &minMines,
+					MaxValue:    maxMines,
+				},
+			},
+		},
+		{
+			Name:        "keno",
+			Description: "Pick up to 10 numbers from 1 to 80. 20 are drawn, and the more you catch the more you win!",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "wager",
+					Description: "The amount of chips you want to bet.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "numbers",
+					Description: "Your numbers, separated by spaces, e.g. \"7 21 33 64\". Leave out for a quick pick.",
+					Required:    false,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "quick_pick",
+					Description: "How many numbers to pick at random, if you don't pick your own. 10 by default.",
+					Required:    false,
+					MinValue:    &minKenoSpots,
+					MaxValue:    maxKenoSpots,
+				},
+			},
+		},
+		{
+			Name:        "lottery",
+			Description: "The daily lottery. One ticket wins the whole pot!",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "buy",
+					Description: "Buy tickets for the next draw.",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "tickets",
+							Description: "How many tickets to buy.",
+							Required:    true,
+							MinValue:    &minTickets,
+							MaxValue:    maxTickets,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "info",
+					Description: "See the pot, your tickets and when the next draw is.",
+				},
+			},
+		},
+		{
+			Name:        "hilo",
+			Description: "Guess if the next card is higher or lower. Aces are low, and the same rank is a push.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "wager",
+					Description: "The amount of chips you want to bet.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+			},
+		},
+		{
+			Name:        "war",
+			Description: "You and the dealer draw a card each, and the higher card wins. Tie? Go to war!",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "wager",
+					Description: "The amount of chips you want to bet.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+			},
+		},
+		{
+			Name:        "language",
+			Description: "Change the language the bot talks to you in.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "me",
+					Description: "Set the language for just you.",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "language",
+							Description: "The language to use. Discord default goes back to the language of the server, or your Discord.",
+							Required:    true,
+							Choices:     languageChoices,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "server",
+					Description: "Set the language for everyone in the server. Needs the Manage Server permission.",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionString,
+							Name:        "language",
+							Description: "The language to use. Discord default goes back to the language of each member's Discord.",
+							Required:    true,
+							Choices:     languageChoices,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:        "daily",
+			Description: "Claim your daily chips. Claim them on consecutive days for a streak bonus!",
+		},
+		{
+			Name:        "hourly",
+			Description: "Claim your hourly chips.",
+		},
+		{
+			Name:        "give",
+			Description: "Give some of your chips to another member.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "member",
+					Description: "The member you want to give chips to.",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "amount",
+					Description: "The amount of chips you want to give.",
+					Required:    true,
+					MinValue:    &minWager,
+				},
+			},
+		},
+		{
+			Name:        "loan",
+			Description: "Borrow chips from the house, and pay them back with interest.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "take",
+					Description: "Borrow chips, up to your credit limit.",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "amount",
+							Description: "The amount of chips you want to borrow.",
+							Required:    true,
+							MinValue:    &minWager,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "repay",
+					Description: "Pay back some or all of your loan.",
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Type:        discordgo.ApplicationCommandOptionInteger,
+							Name:        "amount",
+							Description: "The amount of chips you want to pay back. Leave out to pay off the whole loan.",
+							MinValue:    &minWager,
+						},
+					},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionSubCommand,
+					Name:        "status",
+					Description: "See what you owe, when it's due and how much you can borrow.",
+				},
+			},
+		},
+		{
+			Name:        "profile",
+			Description: "See a member's chips, record and loans.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "member",
+					Description: "The member whose profile you want to see. Leave out to see your own.",
+				},
+			},
+		},
 	}
 
 	// commandHandlers is a list of the command handlers for each command
@@ -100,11 +670,7 @@ var (
 			_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Content: fmt.Sprintf(
-						"%s, your chip total is: %d",
-						i.Member.User.Username,
-						chipTotal,
-					),
+					Content: T(LocaleFor(i), "balance", i.Member.User.Username, chipTotal),
 				},
 			})
 		},
@@ -116,7 +682,7 @@ var (
 			_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Content: GetLeaderboard(i.Member.User.Username, option),
+					Content: GetLeaderboard(LocaleFor(i), i.Member.User.Username, option),
 				},
 			})
 
@@ -131,33 +697,11 @@ var (
 			}
 
 			player := dba.FindPlayer(i.Member.User.Username)
+			locale := LocaleFor(i)
 
 			// Checking if there is a game being played in this channel
-			game := FindGameByChannelID(i.ChannelID)
-			// There is a game being played on this channel
-			if game != nil {
-
-				// If the player of the ongoing game is the player sending the command
-				if game.Player == player {
-					_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-						Type: discordgo.InteractionResponseChannelMessageWithSource,
-						Data: &discordgo.InteractionResponseData{
-							Content: "We're already playing a game here!",
-						},
-					})
-				} else {
-					_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-						Type: discordgo.InteractionResponseChannelMessageWithSource,
-						Data: &discordgo.InteractionResponseData{
-							Content: fmt.Sprintf(
-								"I'm currently playing a game with %s in this channel. Please try another channel.",
-								game.Player.Username,
-							),
-						},
-					})
-				}
+			if !CheckChannelFree(i, player) {
 				return
-
 			}
 
 			// Getting the wager amount from the command option. It is validated to be an integer > 1 by the command settings
@@ -168,10 +712,7 @@ var (
 				_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 					Type: discordgo.InteractionResponseChannelMessageWithSource,
 					Data: &discordgo.InteractionResponseData{
-						Content: fmt.Sprintf(
-							"You don't have enough chips for that wager! Your current balance is: %d",
-							player.Chips,
-						),
+						Content: T(locale, "game.not_enough_chips", player.Chips),
 					},
 				})
 				return
@@ -188,30 +729,18 @@ var (
 					_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 						Type: discordgo.InteractionResponseChannelMessageWithSource,
 						Data: &discordgo.InteractionResponseData{
-							Content: fmt.Sprintf(
-								"Stopping your other game to start a new one! Your previous wager of %d was forfeited.",
-								game.Wager,
-							),
+							Content: TN(locale, "game.forfeit", game.Wager, game.Wager),
 						},
 					})
-					startingChips := player.Chips
-					// subtracting the wager unless it would put them below 1 chip
-					if player.Chips-game.Wager >= 1 {
-						player.Chips -= game.Wager
-					} else {
-						player.Chips = 1
-					}
-					// only updating the player if their chips actually changed
-					if startingChips != player.Chips {
-						dba.UpdatePlayer(player)
-					}
+					// The forfeited wager is already out of their chips in escrow, so it's settled as a loss
+					dba.SettleEscrow(game.EscrowID, &player, locale, -game.Wager)
 
 				} else {
 
 					_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 						Type: discordgo.InteractionResponseChannelMessageWithSource,
 						Data: &discordgo.InteractionResponseData{
-							Content: "You're currently in a game elsewhere! Finish that game first, or use the force flag to start a new game.",
+							Content: T(locale, "game.in_game_force"),
 						},
 					})
 					return
@@ -222,47 +751,55 @@ var (
 				_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 					Type: discordgo.InteractionResponseChannelMessageWithSource,
 					Data: &discordgo.InteractionResponseData{
-						Content: fmt.Sprintf(
-							"Starting a new game of blackjack with %s!",
-							player.Username,
-						),
+						Content: T(locale, "blackjack.starting", player.Username),
 					},
 				})
 			}
 
-			// Getting the type of the channel the message was sent on
-			currentChannel, _ := s.Channel(i.ChannelID)
-			gameChannel := currentChannel
-
-			// Checking if this message was sent in a guild text channel. If it was, we want to make a thread
-			if currentChannel.Type == discordgo.ChannelTypeGuildText {
-				// Creating the thread for the game
-				var err error
-				gameChannel, err = s.ThreadStart(i.ChannelID, "Blackjack with "+i.Member.User.Username, discordgo.ChannelTypeGuildPublicThread, 60)
-				if err != nil {
-					log.Fatal(err)
-				}
+			StartBlackjack(i, player, wager, false)
+
+		},
+		"blackjack-trainer": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+			player := dba.FindPlayer(i.Member.User.Username)
+
+			// Checking if there is a game being played in this channel
+			if !CheckChannelFree(i, player) {
+				return
 			}
 
-			// Creating the game and setting the game channel
-			newGame := NewBlackjack(player, wager)
-			newGame.ChannelID = gameChannel.ID
-			// Adding the game to the map
-			BlackjackGamesMap[player.Username] = &newGame
-
-			// Creating the message to display to the player at the start of the game
-			message := newGame.GetDealerHand() + "\n\n"
-			message += newGame.RunPlayerTurn()
-
-			// If the player gets dealt a 21
-			if !newGame.IsPlayersTurn {
-				_, _ = s.ChannelMessageSend(newGame.ChannelID, message)
-				newGame.RunDealerTurn()
-				GameOver(newGame)
-			} else {
-				DisplayHitStandButtons(newGame.ChannelID, message)
+			// Checking if the player starting the game is currently in a game already
+			if _, ok := BlackjackGamesMap[player.Username]; ok {
+				_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseChannelMessageWithSource,
+					Data: &discordgo.InteractionResponseData{
+						Content: T(LocaleFor(i), "game.in_game"),
+					},
+				})
+				return
 			}
 
+			_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: T(LocaleFor(i), "blackjack.trainer_starting", player.Username),
+				},
+			})
+
+			StartBlackjack(i, player, 0, true)
+
+		},
+		"trainer-stats": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+			player := dba.FindPlayer(i.Member.User.Username)
+
+			_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{
+					Content: GetTrainerStats(LocaleFor(i), player) + "\n" + GetCountingStats(LocaleFor(i), player),
+				},
+			})
+
 		},
 		"hit": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
@@ -271,7 +808,7 @@ var (
 
 			// if the game is nil we need to remove the buttons because the game is over now
 			if game == nil {
-				RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+				RemoveButtonsFromInteractionMessage(i)
 				return
 			}
 
@@ -284,31 +821,27 @@ var (
 				return
 			}
 
-			// Removing the buttons from the message so they can't be used again.
-			RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+			// The game's message is edited in place, so the button press just needs acknowledging
+			DeferMessageUpdate(i)
 
 			// If it was from the player
+			message := ""
+			if game.Trainer {
+				message += CheckTrainerDecision(game, PlayHit)
+			}
 			game.Hit(&game.PlayerHand)
-			message := "You chose to hit!\n"
-			message += game.RunPlayerTurn()
+			message += T(game.Locale, "blackjack.hit")
 
 			// If the player's turn is now over, which happens if they bust or get 21
-			if !game.IsPlayersTurn {
-				_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-					Type: discordgo.InteractionResponseChannelMessageWithSource,
-					Data: &discordgo.InteractionResponseData{
-						Content: message,
-					},
-				})
+			if turn := game.RunPlayerTurn(); !game.IsPlayersTurn {
 				// Dealer doesn't need to go if the player busted
 				if game.PlayerHand.Value() == 21 {
-					game.IsPlayersTurn = false
 					game.RunDealerTurn()
 				}
-				GameOver(*game)
+				EndBlackjack(*game, message+turn)
 			} else {
 				// The player's turn is not over
-				RespondHitStandButtons(i, message)
+				game.ShowTable(message+T(game.Locale, "blackjack.hit_or_stand"), BlackjackColorPlaying, hitStandButtons(game.Locale))
 			}
 
 		},
@@ -318,7 +851,7 @@ var (
 
 			// if the game is nil we need to remove the buttons because the game is over now
 			if game == nil {
-				RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+				RemoveButtonsFromInteractionMessage(i)
 				return
 			}
 
@@ -331,24 +864,250 @@ var (
 				return
 			}
 
-			// Removing the buttons from the message so they can't be used again.
-			RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+			// The game's message is edited in place, so the button press just needs acknowledging
+			DeferMessageUpdate(i)
 
 			// If it was from the player
+			message := ""
+			if game.Trainer {
+				message += CheckTrainerDecision(game, PlayStand)
+			}
 			game.IsPlayersTurn = false
-			_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
-				Data: &discordgo.InteractionResponseData{
-					Content: "\nYou stand! It is now the dealer's turn.",
-				},
-			})
 			game.RunDealerTurn()
-			GameOver(*game)
+			EndBlackjack(*game, message+T(game.Locale, "blackjack.stand"))
 
 		},
+		"hint": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+			game := FindGameByChannelID(i.ChannelID)
+
+			// if the game is nil we need to remove the buttons because the game is over now
+			if game == nil {
+				RemoveButtonsFromInteractionMessage(i)
+				return
+			}
+
+			// Only the player in the game can ask for a hint
+			if i.Member.User.Username != game.Player.Username {
+				AcknowledgeInteraction(i)
+				return
+			}
+
+			// Leaving the buttons on the message, the hint is only visible to the player so they can still make their choice
+			advice := game.Rules.BestPlay(game.PlayerHand, game.DealerHand[0])
+			RespondEphemeral(i, advice.ExplanationIn(game.Locale))
+
+		},
+
+		"count-trainer": CountTrainerCommand,
+		"count-answer":  CountAnswerButton,
+		"count-modal":   CountModalSubmit,
+		"count-stop":    CountStopButton,
+
+		"roulette": RouletteCommand,
+
+		"roulette-table":        RouletteTableCommand,
+		"roulette-table-type":   RouletteTableSelect,
+		"roulette-table-amount": RouletteTableSelect,
+		"roulette-table-place":  RouletteTablePlace,
+		"roulette-table-inside": RouletteTableInside,
+		"roulette-table-modal":  RouletteTableModalSubmit,
+		"roulette-table-clear":  RouletteTableClear,
+
+		"slots": SlotsCommand,
+
+		"jackpot": JackpotCommand,
+
+		"poker":             PokerCommand,
+		"poker-join":        PokerJoinButton,
+		"poker-leave":       PokerLeaveButton,
+		"poker-deal":        PokerDealButton,
+		"poker-cards":       PokerCardsButton,
+		"poker-fold":        PokerActionButton,
+		"poker-call":        PokerActionButton,
+		"poker-allin":       PokerActionButton,
+		"poker-raise":       PokerRaiseButton,
+		"poker-raise-modal": PokerRaiseModalSubmit,
+
+		"videopoker":        VideoPokerCommand,
+		"videopoker-hold-0": VideoPokerHoldButton,
+		"videopoker-hold-1": VideoPokerHoldButton,
+		"videopoker-hold-2": VideoPokerHoldButton,
+		"videopoker-hold-3": VideoPokerHoldButton,
+		"videopoker-hold-4": VideoPokerHoldButton,
+		"videopoker-draw":   VideoPokerDrawButton,
+
+		"baccarat": BaccaratCommand,
+
+		"craps": CrapsCommand,
+
+		"duel":         DuelCommand,
+		"duel-accept":  DuelAcceptButton,
+		"duel-decline": DuelDeclineButton,
+		"bjduel-hit":   BlackjackDuelButton,
+		"bjduel-stand": BlackjackDuelButton,
+		"bjduel-hand":  BlackjackDuelButton,
+
+		"crash":         CrashCommand,
+		"crash-cashout": CrashCashOutButton,
+
+		"mines":         MinesCommand,
+		"mines-cashout": MinesCashOutButton,
+		"mines-0":       MinesTileButton,
+		"mines-1":       MinesTileButton,
+		"mines-2":       MinesTileButton,
+		"mines-3":       MinesTileButton,
+		"mines-4":       MinesTileButton,
+		"mines-5":       MinesTileButton,
+		"mines-6":       MinesTileButton,
+		"mines-7":       MinesTileButton,
+		"mines-8":       MinesTileButton,
+		"mines-9":       MinesTileButton,
+		"mines-10":      MinesTileButton,
+		"mines-11":      MinesTileButton,
+		"mines-12":      MinesTileButton,
+		"mines-13":      MinesTileButton,
+		"mines-14":      MinesTileButton,
+		"mines-15":      MinesTileButton,
+		"mines-16":      MinesTileButton,
+		"mines-17":      MinesTileButton,
+		"mines-18":      MinesTileButton,
+		"mines-19":      MinesTileButton,
+		"mines-20":      MinesTileButton,
+		"mines-21":      MinesTileButton,
+		"mines-22":      MinesTileButton,
+		"mines-23":      MinesTileButton,
+		"mines-24":      MinesTileButton,
+
+		"keno":    KenoCommand,
+		"lottery": LotteryCommand,
+
+		"hilo":         HiLoCommand,
+		"hilo-higher":  HiLoButton,
+		"hilo-lower":   HiLoButton,
+		"hilo-cashout": HiLoButton,
+
+		"war":           WarCommand,
+		"war-go":        WarButton,
+		"war-surrender": WarButton,
+
+		"language": LanguageCommand,
+
+		"daily":  RewardCommand,
+		"hourly": RewardCommand,
+
+		"give":         GiveCommand,
+		"give-confirm": GiveButton,
+		"give-cancel":  GiveButton,
+
+		"loan":    LoanCommand,
+		"profile": ProfileCommand,
 	}
 )
 
+// CheckChannelFree checks if a game of blackjack is already being played in the channel of the interaction.
+// If there is, it responds to the interaction letting the player know, and returns false.
+func CheckChannelFree(i *discordgo.InteractionCreate, player Player) bool {
+
+	game := FindGameByChannelID(i.ChannelID)
+	// There is no game being played on this channel
+	if game == nil {
+		return true
+	}
+
+	// If the player of the ongoing game is the player sending the command
+	if game.Player == player {
+		_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: T(LocaleFor(i), "game.channel_ours"),
+			},
+		})
+	} else {
+		_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: T(LocaleFor(i), "game.channel_busy", game.Player.Username),
+			},
+		})
+	}
+	return false
+
+}
+
+// StartBlackjack creates a new game of blackjack for the player, in a new thread if the interaction came from a text channel.
+// The interaction must already have been responded to.
+func StartBlackjack(i *discordgo.InteractionCreate, player Player, wager int, trainer bool) {
+
+	// Creating the game and setting the game channel
+	newGame := NewBlackjack(player, wager)
+	newGame.Locale = LocaleFor(i)
+
+	if !trainer {
+		// Holding the wager in escrow until the game is over, so the chips can't be spent elsewhere while it's played
+		escrowID, err := dba.EscrowChips(&newGame.Player, wager)
+		if err != nil {
+			_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+				Content: T(newGame.Locale, "game.not_enough_chips", dba.GetChipTotal(player.Username)),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			})
+			if err != nil {
+				log.Println(err)
+			}
+			return
+		}
+		newGame.EscrowID = escrowID
+	}
+
+	newGame.ChannelID = StartGameThread(i, T(newGame.Locale, "blackjack.title", i.Member.User.Username))
+	newGame.ID = i.ID
+	newGame.Trainer = trainer
+	// Adding the game to the map
+	BlackjackGamesMap[player.Username] = &newGame
+
+	// If the player gets dealt a 21
+	if turn := newGame.RunPlayerTurn(); !newGame.IsPlayersTurn {
+		newGame.RunDealerTurn()
+		EndBlackjack(newGame, turn)
+	} else {
+		// Showing the game in a message, which is edited in place from now on
+		newGame.ShowTable(T(newGame.Locale, "blackjack.hit_or_stand"), BlackjackColorPlaying, hitStandButtons(newGame.Locale))
+	}
+
+}
+
+// StartGameThread returns the ID of the channel a game started by the interaction should be played in.
+// If the interaction came from a guild text channel a new thread is made for the game, otherwise it is the same channel.
+func StartGameThread(i *discordgo.InteractionCreate, name string) string {
+
+	// Getting the type of the channel the message was sent on
+	currentChannel, _ := s.Channel(i.ChannelID)
+	gameChannel := currentChannel
+
+	// Checking if this message was sent in a guild text channel. If it was, we want to make a thread
+	if currentChannel.Type == discordgo.ChannelTypeGuildText {
+		// Creating the thread for the game
+		var err error
+		gameChannel, err = s.ThreadStart(i.ChannelID, name, discordgo.ChannelTypeGuildPublicThread, 60)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return gameChannel.ID
+
+}
+
+// EndBlackjack finishes a game of blackjack, using the trainer results if it was a trainer game.
+// The message is what happened on the player's last turn, and is shown before the results.
+func EndBlackjack(game Blackjack, message string) {
+	if game.Trainer {
+		TrainerGameOver(game, message)
+	} else {
+		GameOver(game, message)
+	}
+}
+
 // AcknowledgeInteraction sends an empty response to an interaction then deletes it. To acknowledge the interaction without
 // leaving a response
 func AcknowledgeInteraction(i *discordgo.InteractionCreate) {
@@ -373,7 +1132,7 @@ func RemoveComponentsFromMessage(channelID string, messageID string, content str
 	})
 
 	if err != nil {
-		log.Fatal(err)
+		log.Println(err)
 	}
 }
 
@@ -393,65 +1152,70 @@ func FindGameByChannelID(channelID string) *Blackjack {
 	return nil
 }
 
-// DisplayHitStandButtons takes a channelID and a message and sends the message with hit and stand buttons added.
-func DisplayHitStandButtons(channelID string, message string) {
-
-	_, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
-		Content: message,
-		Components: []discordgo.MessageComponent{
-			discordgo.ActionsRow{
-				Components: []discordgo.MessageComponent{
-					discordgo.Button{
-						Label:    "Hit",
-						Style:    discordgo.SuccessButton,
-						CustomID: "hit",
-					},
-					discordgo.Button{
-						Label:    "Stand",
-						Style:    discordgo.DangerButton,
-						CustomID: "stand",
-					},
+// hitStandButtons returns the row of buttons a player uses to take their turn in blackjack, in the game's language
+func hitStandButtons(locale string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    T(locale, "blackjack.hit_button"),
+					Style:    discordgo.SuccessButton,
+					CustomID: "hit",
+				},
+				discordgo.Button{
+					Label:    T(locale, "blackjack.stand_button"),
+					Style:    discordgo.DangerButton,
+					CustomID: "stand",
+				},
+				discordgo.Button{
+					Label:    T(locale, "blackjack.hint_button"),
+					Style:    discordgo.SecondaryButton,
+					CustomID: "hint",
 				},
 			},
 		},
-	})
+	}
+}
 
+// DeferMessageUpdate acknowledges a button press on a message that will be edited separately
+func DeferMessageUpdate(i *discordgo.InteractionCreate) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
 	if err != nil {
-		log.Fatal(err)
+		log.Println(err)
 	}
 }
 
-// RespondHitStandButtons takes an interaction and responds to it with hit and stand buttons
-func RespondHitStandButtons(i *discordgo.InteractionCreate, message string) {
-
+// RemoveButtonsFromInteractionMessage responds to a button press by removing the buttons from its message, keeping
+// the content and embeds as they are
+func RemoveButtonsFromInteractionMessage(i *discordgo.InteractionCreate) {
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Type: discordgo.InteractionResponseUpdateMessage,
 		Data: &discordgo.InteractionResponseData{
-			Content: message,
-			Components: []discordgo.MessageComponent{
-				discordgo.ActionsRow{
-					Components: []discordgo.MessageComponent{
-						discordgo.Button{
-							Label:    "Hit",
-							Style:    discordgo.SuccessButton,
-							CustomID: "hit",
-						},
-						discordgo.Button{
-							Label:    "Stand",
-							Style:    discordgo.DangerButton,
-							CustomID: "stand",
-						},
-					},
-				},
-			},
+			Content:    i.Message.Content,
+			Embeds:     i.Message.Embeds,
+			Components: []discordgo.MessageComponent{},
 		},
 	})
 	if err != nil {
-		log.Fatal(err)
+		log.Println(err)
 	}
 }
 
-func init() {
+// RespondEphemeral responds to an interaction with a message only the user who sent it can see
+func RespondEphemeral(i *discordgo.InteractionCreate, message string) {
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: message,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// addInteractionHandler Adds the handler that sends each interaction to its command handler
+func addInteractionHandler() {
 
 	// Adding a handler to the session to handle InteractionCreate events (slash command)
 	s.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -466,6 +1230,10 @@ func init() {
 			if h, ok := commandHandlers[i.MessageComponentData().CustomID]; ok {
 				h(s, i)
 			}
+		case discordgo.InteractionModalSubmit:
+			if h, ok := commandHandlers[i.ModalSubmitData().CustomID]; ok {
+				h(s, i)
+			}
 		}
 	})
 
@@ -473,6 +1241,21 @@ func init() {
 
 func main() {
 
+	flag.Parse()
+	Config = GetConfig()
+
+	// Printing the slot machine's payout math, so operators can check it before enabling slots
+	if *slotsReport {
+		machine, err := LoadSlotsConfig(Config.SlotsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(machine.Report())
+		return
+	}
+
+	startup()
+
 	err := s.Open()
 
 	if err != nil {
@@ -499,6 +1282,9 @@ func main() {
 		}
 	}(s)
 
+	// Making the lottery draws in the background, including any that were due while the bot was offline
+	go RunLotteryScheduler()
+
 	fmt.Println("GamblingBot is online.")
 
 	// This makes the bot continue to run a termination signal is sent
@@ -509,54 +1295,43 @@ func main() {
 }
 
 // GameOver updates the BlackjackGame's Player to reflect the results of the game, and updates the entry in the database.
-// Outputs the game results to Discord, and sets GameOn to False.
-func GameOver(game Blackjack) {
-
-	message := game.Results()
-
-	// If it was a draw
-	if game.Wager == 0 {
-		message += " Your wager was returned."
-	} else {
-
-		if game.Wager > 0 {
-
-			// If the wager is positive they are gaining chips
-			message += fmt.Sprintf(" You've gained %d chips!", game.Wager)
-
-		} else {
-			// Negative wager, so they lost
-			// If the wager brings them to zero, we take pity and keep them at one chip.
-			if game.Player.Chips+game.Wager <= 0 {
-				message += "\n\nUh oh, looks like you lost the last of your chips! I'll put your total back up to 1, so you can keep playing."
-				// They will not be able to wager more chips than they have, so if the wager takes them to zero, we can just add MinChips to the wager to keep them at that number of chips.
-				game.Wager += MinChips
-			} else {
-				// wager *-1, so we get the positive number of chips lost
-				message += fmt.Sprintf(" You lost %d chips.", game.Wager*-1)
-			}
-		}
-
-		// Updating the chip balance for the player
-		game.Player.Chips += game.Wager
+// Shows the game results in the game's message, and removes the game from the map.
+func GameOver(game Blackjack, message string) {
+
+	result, color := game.Results()
+	message += "\n\n**" + result + "**"
+
+	// Settling the net against the escrowed wager. If the escrow is already gone the game was forfeited for a new one,
+	// which has taken its place in the map, so there's nothing left to settle.
+	settled, ok := dba.SettleEscrow(game.EscrowID, &game.Player, game.Locale, game.Net)
+	if !ok {
+		game.ShowTable(message, color, []discordgo.MessageComponent{})
+		return
+	}
+	message += settled
 
-		message += fmt.Sprintf("\n\nYour new chip total is: %d", game.Player.Chips)
+	// Checking if the player's hand hit the jackpot
+	if share := BlackjackJackpotShare(game.PlayerHand); share > 0 {
+		before := game.Player.Chips
+		message += PayJackpot(&game.Player, game.Locale, share, "jackpot.reason.sevens")
+		dba.AddChips(game.Player.Username, game.Player.Chips-before)
 	}
-	// Updating the player entry
-	dba.UpdatePlayer(game.Player)
+
+	// Paying off some of the player's loan from their winnings, once their chips are saved
+	message += RepayFromWinnings(&game.Player, game.Locale, game.Net)
 	// Removing the game from the map since it is done now
 	delete(BlackjackGamesMap, game.Player.Username)
-	_, _ = s.ChannelMessageSend(game.ChannelID, message)
+	game.ShowTable(message, color, []discordgo.MessageComponent{})
 
 }
 
-func GetLeaderboard(username string, leaderboardType string) string {
+func GetLeaderboard(locale string, username string, leaderboardType string) string {
 
 	var tbl table.Table
 	var sb strings.Builder
 
 	// Writing the table name
-	sb.WriteString(strings.ToUpper(leaderboardType) + " LEADERBOARD\n")
+	sb.WriteString(T(locale, "leaderboard.title."+leaderboardType) + "\n")
 	sb.WriteString("================================================\n")
 
 	var leaderboard []Player
@@ -565,10 +1340,12 @@ func GetLeaderboard(username string, leaderboardType string) string {
 	switch leaderboardType {
 	case "wins":
 		leaderboard = dba.GetLeaderboard(Wins)
-		tbl = table.New("RANK", "PLAYER", "WINS", "TIES", "LOSSES", "CHIPS")
+		tbl = table.New(T(locale, "leaderboard.rank"), T(locale, "leaderboard.player"), T(locale, "leaderboard.wins"),
+			T(locale, "leaderboard.ties"), T(locale, "leaderboard.losses"), T(locale, "leaderboard.chips"))
 	case "chips":
 		leaderboard = dba.GetLeaderboard(Chips)
-		tbl = table.New("RANK", "PLAYER", "CHIPS", "WINS", "TIES", "LOSSES")
+		tbl = table.New(T(locale, "leaderboard.rank"), T(locale, "leaderboard.player"), T(locale, "leaderboard.chips"),
+			T(locale, "leaderboard.wins"), T(locale, "leaderboard.ties"), T(locale, "leaderboard.losses"))
 	}
 
 	// Looping through the rows, displaying top 5 and player who requested leaderboard
@@ -0,0 +1,402 @@
+// This file implements mines. The player picks how many mines are hidden on a 5x5 grid, then reveals tiles one at a
+// time. Each safe tile raises the multiplier, and the player can cash out whenever they like, but hitting a mine loses
+// the wager. The board is committed to by a hash before the first tile is revealed, and shown in full at the end.
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// MinesGridSize is the number of tiles along each side of the grid
+const MinesGridSize = 5
+
+// MinesTiles is the number of tiles on the grid
+const MinesTiles = MinesGridSize * MinesGridSize
+
+// MinesHouseEdge is taken off the fair multiplier once, however many tiles are revealed
+const MinesHouseEdge = 0.03
+
+// MinesMultiplier Returns the multiplier for revealing the given number of safe tiles, rounded down to two decimals.
+// The fair multiplier is one over the chance of picking that many safe tiles in a row, less the house edge.
+func MinesMultiplier(mines int, revealed int) float64 {
+
+	if revealed == 0 {
+		return 1
+	}
+
+	fair := 1.0
+	for i := 0; i < revealed; i++ {
+		fair *= float64(MinesTiles-i) / float64(MinesTiles-mines-i)
+	}
+
+	return math.Floor(fair*(1-MinesHouseEdge)*100) / 100
+
+}
+
+// Mines A game of mines being played
+type Mines struct {
+	mu sync.Mutex
+
+	Player   Player
+	Wager    int
+	EscrowID int64
+	Mines    int
+	// Board is true for each tile with a mine, and Revealed is true for each tile the player has turned over
+	Board    [MinesTiles]bool
+	Revealed [MinesTiles]bool
+	// Salt is mixed into the board's hash, so the board can't be worked out from the hash before the game ends
+	Salt string
+
+	// Interaction is the command that started the game, whose response is the grid.
+	// The cash out button is on a follow up message, since the grid uses all of the rows of buttons a message can have.
+	Interaction      *discordgo.Interaction
+	CashOutMessageID string
+	// Locale is the language the game is shown in
+	Locale string
+	Over   bool
+}
+
+var (
+	// MinesGamesMap games of mines being played, by the player's username
+	MinesGamesMap = make(map[string]*Mines)
+	minesGamesMu  sync.Mutex
+)
+
+// NewMines Hides the mines on a new board
+func NewMines(player Player, wager int, mines int) *Mines {
+
+	game := &Mines{Player: player, Wager: wager, Mines: mines, Locale: DefaultLocale}
+
+	// Choosing the mine tiles by shuffling every tile and taking the first ones
+	tiles := make([]int, MinesTiles)
+	for i := range tiles {
+		tiles[i] = i
+	}
+	for i := len(tiles) - 1; i > 0; i-- {
+		j := RNG.Intn(i + 1)
+		tiles[i], tiles[j] = tiles[j], tiles[i]
+	}
+	for _, tile := range tiles[:mines] {
+		game.Board[tile] = true
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		log.Fatal(err)
+	}
+	game.Salt = hex.EncodeToString(salt)
+
+	return game
+
+}
+
+// Layout Returns the tiles with mines, numbered from 1 in reading order, e.g. "3,7,12"
+func (g *Mines) Layout() string {
+	var tiles []string
+	for tile, mine := range g.Board {
+		if mine {
+			tiles = append(tiles, strconv.Itoa(tile+1))
+		}
+	}
+	return strings.Join(tiles, ",")
+}
+
+// Hash Returns the SHA-256 hash of the salt and layout, joined by a colon, which commits to the board before play
+func (g *Mines) Hash() string {
+	sum := sha256.Sum256([]byte(g.Salt + ":" + g.Layout()))
+	return hex.EncodeToString(sum[:])
+}
+
+// RevealedCount Returns the number of safe tiles the player has revealed
+func (g *Mines) RevealedCount() int {
+	count := 0
+	for _, revealed := range g.Revealed {
+		if revealed {
+			count++
+		}
+	}
+	return count
+}
+
+// Reveal Turns over a tile, returning true if it was a mine
+func (g *Mines) Reveal(tile int) bool {
+	g.Revealed[tile] = true
+	return g.Board[tile]
+}
+
+// Cleared Returns whether every safe tile has been revealed
+func (g *Mines) Cleared() bool {
+	return g.RevealedCount() == MinesTiles-g.Mines
+}
+
+// Content Returns the grid message, with the multiplier so far and the board's hash
+func (g *Mines) Content() string {
+
+	var sb strings.Builder
+
+	sb.WriteString(T(g.Locale, "mines.playing", g.Player.Username, g.Mines, g.Wager))
+
+	revealed := g.RevealedCount()
+	sb.WriteString(T(g.Locale, "mines.multiplier", MinesMultiplier(g.Mines, revealed)))
+	if !g.Over && !g.Cleared() {
+		sb.WriteString(T(g.Locale, "mines.next_tile", MinesMultiplier(g.Mines, revealed+1)))
+	}
+
+	sb.WriteString(T(g.Locale, "mines.hash", g.Hash()))
+	if g.Over {
+		sb.WriteString(T(g.Locale, "mines.layout", g.Salt, g.Layout()))
+	}
+
+	return sb.String()
+
+}
+
+// Components Returns the grid of tile buttons. Once the game is over the whole board is shown and the buttons are disabled.
+func (g *Mines) Components() []discordgo.MessageComponent {
+
+	var rows []discordgo.MessageComponent
+
+	for row := 0; row < MinesGridSize; row++ {
+		var buttons []discordgo.MessageComponent
+		for column := 0; column < MinesGridSize; column++ {
+			tile := row*MinesGridSize + column
+			button := discordgo.Button{
+				Label:    "❔",
+				Style:    discordgo.SecondaryButton,
+				CustomID: "mines-" + strconv.Itoa(tile),
+				Disabled: g.Over || g.Revealed[tile],
+			}
+			if g.Revealed[tile] || g.Over {
+				button.Label = "💎"
+				button.Style = discordgo.SuccessButton
+				if g.Board[tile] {
+					button.Label = "💣"
+					button.Style = discordgo.DangerButton
+				}
+				if !g.Revealed[tile] {
+					button.Style = discordgo.SecondaryButton
+				}
+			}
+			buttons = append(buttons, button)
+		}
+		rows = append(rows, discordgo.ActionsRow{Components: buttons})
+	}
+
+	return rows
+
+}
+
+// settle ends the game, paying the player their wager times the multiplier if they cashed out.
+// Returns a message with the result.
+func (g *Mines) settle(cashedOut bool) string {
+
+	g.Over = true
+
+	minesGamesMu.Lock()
+	delete(MinesGamesMap, g.Player.Username)
+	minesGamesMu.Unlock()
+
+	// Settling against the escrowed wager, so anything the player won or spent while they played is kept
+	if !cashedOut {
+		message, _ := dba.SettleEscrow(g.EscrowID, &g.Player, g.Locale, -g.Wager)
+		return T(g.Locale, "mines.boom", g.Player.Username) + message
+	}
+
+	multiplier := MinesMultiplier(g.Mines, g.RevealedCount())
+	payout := int(float64(g.Wager) * multiplier)
+	message, _ := dba.SettleEscrow(g.EscrowID, &g.Player, g.Locale, payout-g.Wager)
+
+	return T(g.Locale, "mines.cashed_out", g.Player.Username, multiplier, payout) + message
+
+}
+
+// MinesCommand handles the /mines command, hiding the mines and showing the player the grid
+func MinesCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	// Getting options and storing in map
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	wager := int(optionMap["wager"].IntValue())
+	mines := int(optionMap["mines"].IntValue())
+	locale := LocaleFor(i)
+
+	player := dba.FindPlayer(i.Member.User.Username)
+	if player.Chips < wager {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", player.Chips))
+		return
+	}
+
+	minesGamesMu.Lock()
+	if _, ok := MinesGamesMap[player.Username]; ok {
+		minesGamesMu.Unlock()
+		RespondEphemeral(i, T(locale, "mines.in_game"))
+		return
+	}
+	game := NewMines(player, wager, mines)
+	game.Interaction = i.Interaction
+	game.Locale = locale
+
+	// Holding the wager in escrow until the game is over, so it can be refunded if the bot stops mid game
+	escrowID, err := dba.EscrowChips(&game.Player, wager)
+	if err != nil {
+		minesGamesMu.Unlock()
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", dba.GetChipTotal(player.Username)))
+		return
+	}
+	game.EscrowID = escrowID
+	MinesGamesMap[player.Username] = game
+	minesGamesMu.Unlock()
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    game.Content(),
+			Components: game.Components(),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	message, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: T(locale, "mines.cash_out_prompt"),
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{Label: T(locale, "mines.cash_out_button"), Style: discordgo.SuccessButton, CustomID: "mines-cashout"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	game.CashOutMessageID = message.ID
+
+}
+
+// findMinesGame Returns the game being played by the player who pressed a button, as long as the button is on that
+// game's messages. Otherwise the interaction is responded to and nil is returned.
+func findMinesGame(i *discordgo.InteractionCreate) *Mines {
+
+	minesGamesMu.Lock()
+	game, ok := MinesGamesMap[i.Member.User.Username]
+	minesGamesMu.Unlock()
+
+	onGrid := ok && i.Message.Interaction != nil && i.Message.Interaction.ID == game.Interaction.ID
+	if !ok || (!onGrid && i.Message.ID != game.CashOutMessageID) {
+		RespondEphemeral(i, T(LocaleFor(i), "mines.not_yours"))
+		return nil
+	}
+
+	return game
+
+}
+
+// MinesTileButton handles the tile buttons, revealing the tile and ending the game if it was a mine
+func MinesTileButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	game := findMinesGame(i)
+	if game == nil {
+		return
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	tile, _ := strconv.Atoi(strings.TrimPrefix(i.MessageComponentData().CustomID, "mines-"))
+	if game.Over || game.Revealed[tile] {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	content := ""
+	if game.Reveal(tile) {
+		content = "\n\n" + game.settle(false)
+	} else if game.Cleared() {
+		// Cashing out automatically once there are no safe tiles left
+		content = "\n\n" + game.settle(true)
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    game.Content() + content,
+			Components: game.Components(),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+	// Removing the cash out button once the game is over
+	if game.Over {
+		finished := T(game.Locale, "mines.over")
+		_, err = s.FollowupMessageEdit(game.Interaction, game.CashOutMessageID, &discordgo.WebhookEdit{
+			Content:    &finished,
+			Components: &[]discordgo.MessageComponent{},
+		})
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+}
+
+// MinesCashOutButton handles the cash out button, paying the player for the tiles they've revealed
+func MinesCashOutButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	game := findMinesGame(i)
+	if game == nil {
+		return
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if game.Over {
+		AcknowledgeInteraction(i)
+		return
+	}
+	if game.RevealedCount() == 0 {
+		RespondEphemeral(i, T(game.Locale, "mines.reveal_first"))
+		return
+	}
+
+	message := game.settle(true)
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    message,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+	// Showing the whole board on the grid
+	content := game.Content()
+	components := game.Components()
+	_, err = s.InteractionResponseEdit(game.Interaction, &discordgo.WebhookEdit{Content: &content, Components: &components})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
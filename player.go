@@ -11,3 +11,54 @@ type Player struct {
 	Ties     int
 	Losses   int
 }
+
+// ApplyNetIn Adds the net chips won or lost in a game to the player's chip total, and returns a message describing it
+// in the given language. If a loss would take the player to zero chips, we take pity and keep them at MinChips so they
+// can keep playing.
+func (p *Player) ApplyNetIn(locale string, net int) string {
+
+	// If it was a draw
+	if net == 0 {
+		return T(locale, "net.returned")
+	}
+
+	message := ""
+
+	if net > 0 {
+
+		// If the net is positive they are gaining chips
+		message += TN(locale, "net.gained", net, net)
+
+	} else {
+		// Negative net, so they lost
+		// If the loss brings them to zero, we take pity and keep them at one chip.
+		if p.Chips+net <= 0 {
+			message += T(locale, "net.pity", MinChips)
+			// Setting the loss to leave them with MinChips. Games that hold the wager in escrow put it back in their chips first, so this only happens when the wager was the last of them.
+			net = MinChips - p.Chips
+		} else {
+			// net *-1, so we get the positive number of chips lost
+			message += TN(locale, "net.lost", -net, -net)
+		}
+	}
+
+	// Updating the chip balance for the player
+	p.Chips += net
+
+	message += T(locale, "net.total", p.Chips)
+
+	return message
+
+}
+
+// AddResult Updates the player's wins, ties or losses stat for a game with the given net chips won or lost
+func (p *Player) AddResult(net int) {
+	switch {
+	case net > 0:
+		p.Wins++
+	case net < 0:
+		p.Losses++
+	default:
+		p.Ties++
+	}
+}
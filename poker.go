@@ -0,0 +1,1036 @@
+// This file implements no-limit Texas Hold'em between members. Players buy in to a table in a thread, and play hands
+// against each other with blinds, betting rounds, side pots for all-ins and a showdown. The house can take a rake.
+package main
+
+import (
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// MaxPokerSeats is the most players that can sit at a table
+const MaxPokerSeats = 9
+
+// PokerTurnTimeout is how long a player has to act before they're folded
+const PokerTurnTimeout = 2 * time.Minute
+
+// Poker actions
+const (
+	PokerFold  = "fold"
+	PokerCall  = "call"
+	PokerRaise = "raise"
+	PokerAllIn = "allin"
+)
+
+// PokerSeat A player sitting at a poker table
+type PokerSeat struct {
+	Username string
+	UserID   string
+	Stack    int
+	// BoughtIn is the chips the player brought to the table, to work out their result when they leave
+	BoughtIn int
+	// EscrowID holds the player's stack until they leave, so it's refunded if the bot stops with them at the table.
+	// It starts as the buy in and is kept in step with the stack after every hand.
+	EscrowID int64
+
+	Hole []Card
+	// Bet is the chips put in during the current betting round, Committed is the chips put in during the whole hand
+	Bet       int
+	Committed int
+	InHand    bool
+	Folded    bool
+	AllIn     bool
+	// Acted is whether the player has acted since the last full raise. A player who has acted can only call or fold
+	// when an all in for less than a full raise comes back to them.
+	Acted bool
+}
+
+// canAct Returns whether the seat still has decisions to make this hand
+func (p *PokerSeat) canAct() bool {
+	return p.InHand && !p.Folded && !p.AllIn
+}
+
+// live Returns whether the seat can still win the pot
+func (p *PokerSeat) live() bool {
+	return p.InHand && !p.Folded
+}
+
+// PokerPot A pot, and the players who can win it. Side pots are made when a player is all in for less than the others.
+type PokerPot struct {
+	Amount   int
+	Eligible []*PokerSeat
+}
+
+// PokerTable A table of Texas Hold'em being played in a channel
+type PokerTable struct {
+	mu sync.Mutex
+
+	ChannelID string
+	MessageID string
+
+	BuyIn      int
+	SmallBlind int
+	BigBlind   int
+
+	Seats  []*PokerSeat
+	Button int
+
+	InHand     bool
+	Deck       Deck
+	Board      []Card
+	CurrentBet int
+	// MinRaise is the smallest amount a bet can be raised by, which is the size of the last raise
+	MinRaise int
+	// Turn is the index of the seat whose turn it is
+	Turn int
+	// turns counts the turns started, so a turn timeout can tell if the player it was waiting on has acted
+	turns int
+
+	// LastAction describes what just happened at the table, shown at the top of the table message
+	LastAction string
+	// Locale is the language of the member who opened the table, which the table is shown in
+	Locale string
+}
+
+var (
+	// PokerTablesMap poker tables, by channel ID
+	PokerTablesMap = make(map[string]*PokerTable)
+	pokerTablesMu  sync.Mutex
+)
+
+// FindPokerTable is a helper function to find the poker table in a channel
+func FindPokerTable(channelID string) *PokerTable {
+	pokerTablesMu.Lock()
+	defer pokerTablesMu.Unlock()
+	return PokerTablesMap[channelID]
+}
+
+// NewPokerTable Creates an empty poker table
+func NewPokerTable(channelID string, buyIn int, smallBlind int) *PokerTable {
+	return &PokerTable{
+		ChannelID:  channelID,
+		BuyIn:      buyIn,
+		SmallBlind: smallBlind,
+		BigBlind:   smallBlind * 2,
+		Button:     -1,
+		Locale:     DefaultLocale,
+	}
+}
+
+// Seat Returns the seat of the player at the table, or nil if they aren't sitting at it
+func (t *PokerTable) Seat(username string) *PokerSeat {
+	for _, seat := range t.Seats {
+		if seat.Username == username {
+			return seat
+		}
+	}
+	return nil
+}
+
+// Sit Seats the player at the table with the buy in, which is taken from the player and held in escrow.
+// The player passed in is updated too.
+func (t *PokerTable) Sit(player *Player, userID string) error {
+
+	if t.Seat(player.Username) != nil {
+		return NewLocalizedError("poker.error.seated")
+	}
+	if len(t.Seats) >= MaxPokerSeats {
+		return NewLocalizedError("poker.error.full")
+	}
+
+	escrowID, err := dba.EscrowChips(player, t.BuyIn)
+	if err != nil {
+		return NewLocalizedError("poker.error.buy_in", t.BuyIn, dba.GetChipTotal(player.Username))
+	}
+
+	t.Seats = append(t.Seats, &PokerSeat{Username: player.Username, UserID: userID, Stack: t.BuyIn, BoughtIn: t.BuyIn, EscrowID: escrowID})
+
+	return nil
+
+}
+
+// Leave Removes the player from the table, returning their seat so their stack can be given back to them.
+// Players can't leave in the middle of a hand they're playing in.
+func (t *PokerTable) Leave(username string) (*PokerSeat, error) {
+
+	for i, seat := range t.Seats {
+		if seat.Username != username {
+			continue
+		}
+		// The chips a player has put in stay in the pot until the hand is over, so they have to wait to leave
+		if t.InHand && seat.InHand {
+			return nil, NewLocalizedError("poker.error.leave_in_hand")
+		}
+		t.Seats = append(t.Seats[:i], t.Seats[i+1:]...)
+		// Keeping the button and turn on the same players
+		if i <= t.Button {
+			t.Button--
+		}
+		if i < t.Turn {
+			t.Turn--
+		}
+		return seat, nil
+	}
+
+	return nil, NewLocalizedError("poker.error.not_seated")
+
+}
+
+// next Returns the index of the next seat after from that matches, or -1 if none do
+func (t *PokerTable) next(from int, matches func(*PokerSeat) bool) int {
+	for offset := 1; offset <= len(t.Seats); offset++ {
+		i := ((from+offset)%len(t.Seats) + len(t.Seats)) % len(t.Seats)
+		if matches(t.Seats[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// count Returns the number of seats that match
+func (t *PokerTable) count(matches func(*PokerSeat) bool) int {
+	n := 0
+	for _, seat := range t.Seats {
+		if matches(seat) {
+			n++
+		}
+	}
+	return n
+}
+
+// put Moves chips from the seat's stack into the pot, going all in if they don't have enough
+func (t *PokerTable) put(seat *PokerSeat, amount int) {
+	if amount >= seat.Stack {
+		amount = seat.Stack
+		seat.AllIn = true
+	}
+	seat.Stack -= amount
+	seat.Bet += amount
+	seat.Committed += amount
+}
+
+// StartHand Moves the button, posts the blinds and deals everyone their hole cards
+func (t *PokerTable) StartHand() error {
+
+	if t.InHand {
+		return NewLocalizedError("poker.error.in_hand")
+	}
+	if t.count(func(p *PokerSeat) bool { return p.Stack > 0 }) < 2 {
+		return NewLocalizedError("poker.error.two_players")
+	}
+
+	t.InHand = true
+	t.Board = nil
+	t.Deck = NewShoe(1)
+	for _, seat := range t.Seats {
+		*seat = PokerSeat{Username: seat.Username, UserID: seat.UserID, Stack: seat.Stack, BoughtIn: seat.BoughtIn, EscrowID: seat.EscrowID, InHand: seat.Stack > 0}
+	}
+
+	inHand := func(p *PokerSeat) bool { return p.InHand }
+	t.Button = t.next(t.Button, inHand)
+
+	// Heads up, the button posts the small blind. Otherwise it's the next two players after the button.
+	smallBlind := t.next(t.Button, inHand)
+	if t.count(inHand) == 2 {
+		smallBlind = t.Button
+	}
+	bigBlind := t.next(smallBlind, inHand)
+	t.put(t.Seats[smallBlind], t.SmallBlind)
+	t.put(t.Seats[bigBlind], t.BigBlind)
+	t.CurrentBet = t.BigBlind
+	t.MinRaise = t.BigBlind
+
+	for _, seat := range t.Seats {
+		if seat.InHand {
+			seat.Hole = []Card{t.Deck.DealCard(), t.Deck.DealCard()}
+		}
+	}
+
+	t.LastAction = T(t.Locale, "poker.new_hand",
+		t.Seats[smallBlind].Username, t.SmallBlind, t.Seats[bigBlind].Username, t.BigBlind)
+
+	// The first player to act is the one after the big blind
+	t.Turn = t.next(bigBlind, (*PokerSeat).canAct)
+	if t.Turn == -1 || t.roundComplete() {
+		// Everyone is all in from the blinds, so the rest of the board is dealt out
+		t.LastAction += "\n" + t.advance()
+	}
+
+	return nil
+
+}
+
+// roundComplete Returns whether everyone who can still act has matched the current bet since the last raise
+func (t *PokerTable) roundComplete() bool {
+
+	canAct := t.count((*PokerSeat).canAct)
+	for _, seat := range t.Seats {
+		if !seat.canAct() {
+			continue
+		}
+		// A single player left to act who has already matched the bet has nobody to bet against
+		if seat.Bet < t.CurrentBet || (!seat.Acted && canAct > 1) {
+			return false
+		}
+	}
+
+	return true
+
+}
+
+// Act Makes a move for the player whose turn it is. For a raise, amount is the total bet being raised to.
+// Returns a message describing what happened.
+func (t *PokerTable) Act(username string, action string, amount int) (string, error) {
+
+	if !t.InHand {
+		return "", NewLocalizedError("poker.error.no_hand")
+	}
+	seat := t.Seats[t.Turn]
+	if seat.Username != username {
+		return "", NewLocalizedError("poker.error.turn", seat.Username)
+	}
+
+	toCall := t.CurrentBet - seat.Bet
+	opened := t.CurrentBet == 0
+	var message string
+
+	switch action {
+	case PokerFold:
+		seat.Folded = true
+		message = T(t.Locale, "poker.folds", username)
+	case PokerCall:
+		if toCall == 0 {
+			message = T(t.Locale, "poker.checks", username)
+		} else {
+			t.put(seat, toCall)
+			message = T(t.Locale, "poker.calls", username, seat.Bet)
+			if seat.AllIn {
+				message = T(t.Locale, "poker.calls_all_in", username, seat.Bet)
+			}
+		}
+	case PokerAllIn:
+		amount = seat.Bet + seat.Stack
+		if amount <= t.CurrentBet {
+			t.put(seat, seat.Stack)
+			message = T(t.Locale, "poker.calls_all_in", username, seat.Bet)
+			break
+		}
+		fallthrough
+	case PokerRaise:
+		if seat.Acted {
+			return "", NewLocalizedError("poker.error.reopen")
+		}
+		if amount > seat.Bet+seat.Stack {
+			return "", NewLocalizedError("poker.error.stack", seat.Bet+seat.Stack)
+		}
+		if amount <= t.CurrentBet {
+			return "", NewLocalizedError("poker.error.raise_more", t.CurrentBet)
+		}
+		// A raise has to be at least as big as the last one, unless the player is going all in
+		if amount-t.CurrentBet < t.MinRaise && amount < seat.Bet+seat.Stack {
+			return "", NewLocalizedError("poker.error.min_raise", t.CurrentBet+t.MinRaise)
+		}
+		// Everyone else has to act again after a full raise. An all in for less only reopens the betting for players
+		// who haven't acted yet, and everyone else can just call or fold.
+		if amount-t.CurrentBet >= t.MinRaise {
+			t.MinRaise = amount - t.CurrentBet
+			for _, other := range t.Seats {
+				other.Acted = false
+			}
+		}
+		t.CurrentBet = amount
+		t.put(seat, amount-seat.Bet)
+
+		key := "poker.raises"
+		if opened {
+			key = "poker.bets"
+		}
+		if seat.AllIn {
+			key += "_all_in"
+		}
+		message = T(t.Locale, key, username, amount)
+	default:
+		return "", NewLocalizedError("poker.error.action", action)
+	}
+
+	seat.Acted = true
+
+	if result := t.advance(); result != "" {
+		message += "\n" + result
+	}
+
+	return message, nil
+
+}
+
+// advance Moves the hand on after an action: to the next player, the next street, or the end of the hand.
+// Returns a message describing any cards dealt or the results of the hand.
+func (t *PokerTable) advance() string {
+
+	// Everyone else folded
+	if t.count((*PokerSeat).live) == 1 {
+		return t.finishHand()
+	}
+
+	if !t.roundComplete() {
+		t.Turn = t.next(t.Turn, (*PokerSeat).canAct)
+		return ""
+	}
+
+	var sb strings.Builder
+
+	// Dealing the next street. If one or nobody can still bet, the rest of the board is dealt straight away.
+	for {
+		if len(t.Board) == 5 {
+			sb.WriteString(t.finishHand())
+			return sb.String()
+		}
+
+		for _, seat := range t.Seats {
+			seat.Bet = 0
+			seat.Acted = false
+		}
+		t.CurrentBet = 0
+		t.MinRaise = t.BigBlind
+
+		// Burning a card, then dealing three cards for the flop or one for the turn and river
+		t.Deck.DealCard()
+		deal := 1
+		street := "turn"
+		if len(t.Board) == 0 {
+			deal = 3
+			street = "flop"
+		} else if len(t.Board) == 4 {
+			street = "river"
+		}
+		for i := 0; i < deal; i++ {
+			t.Board = append(t.Board, t.Deck.DealCard())
+		}
+		sb.WriteString(T(t.Locale, "poker.dealt."+street))
+
+		if t.count((*PokerSeat).canAct) >= 2 {
+			// The first player to act after the flop is the first one after the button
+			t.Turn = t.next(t.Button, (*PokerSeat).canAct)
+			return sb.String()
+		}
+	}
+
+}
+
+// Pots Splits the chips committed this hand into the main pot and any side pots.
+// Each all in amount makes a new level, and a player can only win the pots up to the level they put in.
+func (t *PokerTable) Pots() []PokerPot {
+
+	// Finding the levels, which are the distinct amounts committed by players still in the hand
+	var levels []int
+	for _, seat := range t.Seats {
+		if seat.live() && seat.Committed > 0 && !slices.Contains(levels, seat.Committed) {
+			levels = append(levels, seat.Committed)
+		}
+	}
+	slices.Sort(levels)
+
+	var pots []PokerPot
+	previous := 0
+	for _, level := range levels {
+		pot := PokerPot{}
+		for _, seat := range t.Seats {
+			pot.Amount += min(seat.Committed, level) - min(seat.Committed, previous)
+			if seat.live() && seat.Committed >= level {
+				pot.Eligible = append(pot.Eligible, seat)
+			}
+		}
+		pots = append(pots, pot)
+		previous = level
+	}
+
+	// Any chips from folded players above the highest level go into the last pot
+	for _, seat := range t.Seats {
+		if seat.Committed > previous && len(pots) > 0 {
+			pots[len(pots)-1].Amount += seat.Committed - previous
+		}
+	}
+
+	return pots
+
+}
+
+// finishHand Takes the rake, awards every pot to the best hand eligible for it, and ends the hand.
+// Returns a message with the results.
+func (t *PokerTable) finishHand() string {
+
+	var sb strings.Builder
+	pots := t.Pots()
+
+	// Taking the rake from the pots, starting with the main pot. Following "no flop, no drop", hands that end before
+	// the flop aren't raked.
+	if len(t.Board) >= 3 && Config.PokerRakePercent > 0 {
+		total := 0
+		for _, pot := range pots {
+			total += pot.Amount
+		}
+		rake := int(float64(total) * Config.PokerRakePercent / 100)
+		if Config.PokerRakeCap > 0 {
+			rake = min(rake, Config.PokerRakeCap)
+		}
+		if rake > 0 {
+			sb.WriteString(T(t.Locale, "poker.rake", rake))
+		}
+		for i := range pots {
+			taken := min(rake, pots[i].Amount)
+			pots[i].Amount -= taken
+			rake -= taken
+		}
+	}
+
+	// Showing everyone's hand if it went to a showdown
+	showdown := t.count((*PokerSeat).live) > 1
+	hands := make(map[*PokerSeat]PokerHand)
+	for _, seat := range t.Seats {
+		if seat.live() && showdown {
+			hands[seat] = BestPokerHand(append(append([]Card{}, seat.Hole...), t.Board...))
+			sb.WriteString(T(t.Locale, "poker.shows", seat.Username, RenderCards(seat.Hole), hands[seat].Category.StringIn(t.Locale)))
+		}
+	}
+
+	for i, pot := range pots {
+		if pot.Amount == 0 {
+			continue
+		}
+
+		// Finding the best hand, and everyone who ties with it
+		var winners []*PokerSeat
+		for _, seat := range pot.Eligible {
+			if len(winners) == 0 {
+				winners = []*PokerSeat{seat}
+				continue
+			}
+			switch hands[seat].Compare(hands[winners[0]]) {
+			case 1:
+				winners = []*PokerSeat{seat}
+			case 0:
+				winners = append(winners, seat)
+			}
+		}
+
+		// Splitting the pot. Any odd chips go to the first winners after the button.
+		share := pot.Amount / len(winners)
+		remainder := pot.Amount % len(winners)
+		names := make([]string, len(winners))
+		for j, seat := range t.orderFromButton(winners) {
+			seat.Stack += share
+			if j < remainder {
+				seat.Stack++
+			}
+			names[j] = seat.Username
+		}
+
+		name := T(t.Locale, "poker.pot")
+		if len(pots) > 1 {
+			name = T(t.Locale, "poker.main_pot")
+			if i > 0 {
+				name = T(t.Locale, "poker.side_pot", i)
+			}
+		}
+		sb.WriteString(T(t.Locale, "poker.wins", strings.Join(names, T(t.Locale, "poker.and")), name, pot.Amount))
+	}
+
+	t.InHand = false
+	for _, seat := range t.Seats {
+		seat.Bet = 0
+		seat.Committed = 0
+	}
+
+	return sb.String()
+
+}
+
+// orderFromButton Returns the seats in the order they sit after the button
+func (t *PokerTable) orderFromButton(seats []*PokerSeat) []*PokerSeat {
+	var ordered []*PokerSeat
+	for offset := 1; offset <= len(t.Seats); offset++ {
+		seat := t.Seats[(t.Button+offset)%len(t.Seats)]
+		for _, s := range seats {
+			if s == seat {
+				ordered = append(ordered, seat)
+			}
+		}
+	}
+	return ordered
+}
+
+// Content Returns the table message, showing the board, the pot and every seat
+func (t *PokerTable) Content() string {
+
+	var sb strings.Builder
+
+	sb.WriteString(T(t.Locale, "poker.title", t.SmallBlind, t.BigBlind, t.BuyIn))
+
+	if t.LastAction != "" {
+		sb.WriteString(t.LastAction + "\n\n")
+	}
+
+	if t.InHand {
+		board := T(t.Locale, "poker.no_board")
+		if len(t.Board) > 0 {
+			board = RenderCards(t.Board)
+		}
+		pot := 0
+		for _, seat := range t.Seats {
+			pot += seat.Committed
+		}
+		sb.WriteString(T(t.Locale, "poker.board", board, pot))
+	}
+
+	for i, seat := range t.Seats {
+		line := T(t.Locale, "poker.seat", seat.Username, seat.Stack)
+		if i == t.Button {
+			line += T(t.Locale, "poker.button")
+		}
+		if t.InHand {
+			switch {
+			case !seat.InHand:
+				line += T(t.Locale, "poker.sitting_out")
+			case seat.Folded:
+				line += T(t.Locale, "poker.folded")
+			case seat.AllIn:
+				line += T(t.Locale, "poker.all_in_for", seat.Committed)
+			case seat.Bet > 0:
+				line += T(t.Locale, "poker.bet", seat.Bet)
+			}
+		}
+		if t.InHand && i == t.Turn {
+			line = "➡️ **" + line + "**"
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	if t.InHand {
+		seat := t.Seats[t.Turn]
+		sb.WriteString(T(t.Locale, "poker.turn", seat.UserID))
+	} else {
+		sb.WriteString(T(t.Locale, "poker.between_hands"))
+	}
+
+	return sb.String()
+
+}
+
+// Components Returns the buttons for the table. The call button shows how much the current player has to call.
+func (t *PokerTable) Components() []discordgo.MessageComponent {
+
+	callLabel := T(t.Locale, "poker.check_button")
+	if t.InHand {
+		seat := t.Seats[t.Turn]
+		if toCall := t.CurrentBet - seat.Bet; toCall > 0 {
+			callLabel = T(t.Locale, "poker.call_button", min(toCall, seat.Stack))
+		}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: T(t.Locale, "poker.fold_button"), Style: discordgo.DangerButton, CustomID: "poker-fold", Disabled: !t.InHand},
+				discordgo.Button{Label: callLabel, Style: discordgo.SuccessButton, CustomID: "poker-call", Disabled: !t.InHand},
+				discordgo.Button{Label: T(t.Locale, "poker.raise_button"), Style: discordgo.PrimaryButton, CustomID: "poker-raise", Disabled: !t.InHand},
+				discordgo.Button{Label: T(t.Locale, "poker.all_in_button"), Style: discordgo.PrimaryButton, CustomID: "poker-allin", Disabled: !t.InHand},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: T(t.Locale, "poker.cards_button"), Style: discordgo.SecondaryButton, CustomID: "poker-cards", Disabled: !t.InHand},
+				discordgo.Button{Label: T(t.Locale, "poker.join_button"), Style: discordgo.SecondaryButton, CustomID: "poker-join"},
+				discordgo.Button{Label: T(t.Locale, "poker.leave_button"), Style: discordgo.SecondaryButton, CustomID: "poker-leave"},
+				discordgo.Button{Label: T(t.Locale, "poker.deal_button"), Style: discordgo.SuccessButton, CustomID: "poker-deal", Disabled: t.InHand},
+			},
+		},
+	}
+
+}
+
+// startTurnTimer Folds the player whose turn it is if they don't act within the turn timeout, so an idle player can't
+// hold up the hand and everyone's stacks with it. Should be called with the table locked whenever a turn starts.
+func (t *PokerTable) startTurnTimer() {
+
+	if !t.InHand {
+		return
+	}
+
+	t.turns++
+	turn := t.turns
+
+	time.AfterFunc(PokerTurnTimeout, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		// The player acted in time, or the hand is over
+		if !t.InHand || t.turns != turn {
+			return
+		}
+
+		username := t.Seats[t.Turn].Username
+		message, err := t.Act(username, PokerFold, 0)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		t.LastAction = T(t.Locale, "poker.idle", username) + message
+		t.saveStacks()
+		t.startTurnTimer()
+		t.editTableMessage()
+	})
+
+}
+
+// editTableMessage Edits the table message to show the latest state of the table, for changes that don't come from
+// a button on it
+func (t *PokerTable) editTableMessage() {
+
+	content := t.Content()
+	components := t.Components()
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		Content:    &content,
+		Components: components,
+		ID:         t.MessageID,
+		Channel:    t.ChannelID,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// respondWithTable responds to a button on the table message by editing it to show the latest state of the table
+func (t *PokerTable) respondWithTable(i *discordgo.InteractionCreate) {
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    t.Content(),
+			Components: t.Components(),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// saveStacks Keeps every seat's escrow in step with their stack once a hand is over, so a player at the table when the
+// bot stops gets back the chips they have rather than their buy in. Should be called with the table locked after
+// anything that can end a hand.
+func (t *PokerTable) saveStacks() {
+
+	if t.InHand {
+		return
+	}
+
+	for _, seat := range t.Seats {
+		dba.SetEscrowStake(seat.EscrowID, seat.Stack)
+	}
+
+}
+
+// cashOut gives a player who left the table their stack back, and records whether they won or lost overall.
+// The buy in is already out of their chips, so the escrow is released and the whole stack is added.
+func cashOut(seat *PokerSeat) {
+	dba.CashOutEscrow(seat.EscrowID, seat.Stack, seat.Stack-seat.BoughtIn)
+}
+
+// PokerCommand handles the /poker command, opening a table in a new thread with the player sitting at it
+func PokerCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	// Getting options and storing in map
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	buyIn := int(optionMap["buyin"].IntValue())
+	smallBlind := 1
+	if opt, ok := optionMap["small_blind"]; ok {
+		smallBlind = int(opt.IntValue())
+	}
+
+	locale := LocaleFor(i)
+	if buyIn < smallBlind*2*10 {
+		RespondEphemeral(i, T(locale, "poker.min_buy_in", smallBlind*2*10))
+		return
+	}
+
+	player := dba.FindPlayer(i.Member.User.Username)
+	if player.Chips < buyIn {
+		RespondEphemeral(i, T(locale, "poker.not_enough_chips", player.Chips))
+		return
+	}
+	table := NewPokerTable("", buyIn, smallBlind)
+	table.Locale = locale
+
+	// Taking the buy in from the player who opened the table before the thread is started for it
+	if err := table.Sit(&player, i.Member.User.ID); err != nil {
+		RespondEphemeral(i, T(locale, "poker.cant_open", ErrorIn(locale, err)))
+		return
+	}
+	table.LastAction = T(table.Locale, "poker.sits", player.Username, buyIn)
+
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: T(locale, "poker.opening", player.Username, buyIn, smallBlind, smallBlind*2),
+		},
+	})
+
+	table.ChannelID = StartGameThread(i, T(locale, "poker.thread", player.Username))
+
+	message, err := s.ChannelMessageSendComplex(table.ChannelID, &discordgo.MessageSend{
+		Content:    table.Content(),
+		Components: table.Components(),
+	})
+	if err != nil {
+		log.Println(err)
+		// Giving the buy in back, since nobody can play at the table
+		dba.RefundEscrow(table.Seats[0].EscrowID)
+		return
+	}
+	table.MessageID = message.ID
+
+	pokerTablesMu.Lock()
+	PokerTablesMap[table.ChannelID] = table
+	pokerTablesMu.Unlock()
+
+}
+
+// PokerJoinButton handles the join button, taking the buy in from the player and seating them
+func PokerJoinButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	table := FindPokerTable(i.ChannelID)
+	if table == nil {
+		RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+		return
+	}
+
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
+	player := dba.FindPlayer(i.Member.User.Username)
+	if err := table.Sit(&player, i.Member.User.ID); err != nil {
+		RespondEphemeral(i, T(LocaleFor(i), "poker.cant_join", ErrorIn(LocaleFor(i), err)))
+		return
+	}
+
+	table.LastAction = T(table.Locale, "poker.sits", player.Username, table.BuyIn)
+	table.respondWithTable(i)
+
+}
+
+// PokerLeaveButton handles the leave button, giving the player their stack back
+func PokerLeaveButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	table := FindPokerTable(i.ChannelID)
+	if table == nil {
+		RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+		return
+	}
+
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
+	seat, err := table.Leave(i.Member.User.Username)
+	if err != nil {
+		RespondEphemeral(i, T(LocaleFor(i), "poker.cant_leave", ErrorIn(LocaleFor(i), err)))
+		return
+	}
+	cashOut(seat)
+
+	table.LastAction = T(table.Locale, "poker.leaves", seat.Username, seat.Stack)
+
+	// Closing the table once everyone has left
+	if len(table.Seats) == 0 {
+		pokerTablesMu.Lock()
+		delete(PokerTablesMap, table.ChannelID)
+		pokerTablesMu.Unlock()
+
+		_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    table.LastAction + "\n\n" + T(table.Locale, "poker.closed"),
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+		return
+	}
+
+	table.respondWithTable(i)
+
+}
+
+// PokerDealButton handles the deal button, starting the next hand
+func PokerDealButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	table := FindPokerTable(i.ChannelID)
+	if table == nil {
+		RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+		return
+	}
+
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
+	if table.Seat(i.Member.User.Username) == nil {
+		RespondEphemeral(i, T(LocaleFor(i), "poker.only_players_deal"))
+		return
+	}
+
+	if err := table.StartHand(); err != nil {
+		RespondEphemeral(i, T(LocaleFor(i), "poker.cant_deal", ErrorIn(LocaleFor(i), err)))
+		return
+	}
+	table.saveStacks()
+	table.startTurnTimer()
+
+	table.respondWithTable(i)
+
+}
+
+// PokerCardsButton handles the my cards button, showing the player their hole cards privately
+func PokerCardsButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	table := FindPokerTable(i.ChannelID)
+	if table == nil {
+		RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+		return
+	}
+
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
+	locale := LocaleFor(i)
+	seat := table.Seat(i.Member.User.Username)
+	if seat == nil || !table.InHand || len(seat.Hole) == 0 {
+		RespondEphemeral(i, T(locale, "poker.no_cards"))
+		return
+	}
+
+	message := T(locale, "poker.your_cards", RenderCards(seat.Hole))
+	if len(table.Board) > 0 {
+		message += T(locale, "poker.best_hand", BestPokerHand(append(append([]Card{}, seat.Hole...), table.Board...)).StringIn(locale))
+	}
+
+	RespondEphemeral(i, message)
+
+}
+
+// PokerActionButton handles the fold, call and all in buttons
+func PokerActionButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	table := FindPokerTable(i.ChannelID)
+	if table == nil {
+		RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+		return
+	}
+
+	action := strings.TrimPrefix(i.MessageComponentData().CustomID, "poker-")
+	pokerAct(i, table, action, 0)
+
+}
+
+// PokerRaiseButton handles the raise button, showing the player a form to enter the amount they are raising to
+func PokerRaiseButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	table := FindPokerTable(i.ChannelID)
+	if table == nil {
+		RemoveComponentsFromMessage(i.ChannelID, i.Message.ID, i.Message.Content)
+		return
+	}
+
+	table.mu.Lock()
+	if !table.InHand || table.Seats[table.Turn].Username != i.Member.User.Username {
+		table.mu.Unlock()
+		RespondEphemeral(i, T(LocaleFor(i), "poker.not_your_turn"))
+		return
+	}
+	seat := table.Seats[table.Turn]
+	placeholder := T(LocaleFor(i), "poker.raise_placeholder", min(table.CurrentBet+table.MinRaise, seat.Bet+seat.Stack), seat.Bet+seat.Stack)
+	table.mu.Unlock()
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: "poker-raise-modal",
+			Title:    T(LocaleFor(i), "poker.raise_button"),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "amount",
+							Label:       T(LocaleFor(i), "poker.raise_to"),
+							Style:       discordgo.TextInputShort,
+							Placeholder: placeholder,
+							Required:    true,
+							MaxLength:   10,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// PokerRaiseModalSubmit handles the amount entered to raise to
+func PokerRaiseModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	table := FindPokerTable(i.ChannelID)
+	if table == nil {
+		RespondEphemeral(i, T(LocaleFor(i), "poker.table_closed"))
+		return
+	}
+
+	value := i.ModalSubmitData().Components[0].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
+	amount, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		RespondEphemeral(i, T(LocaleFor(i), "poker.raise_number"))
+		return
+	}
+
+	pokerAct(i, table, PokerRaise, amount)
+
+}
+
+// pokerAct makes a move at the table for the player who sent the interaction, then shows the updated table
+func pokerAct(i *discordgo.InteractionCreate, table *PokerTable, action string, amount int) {
+
+	table.mu.Lock()
+	defer table.mu.Unlock()
+
+	message, err := table.Act(i.Member.User.Username, action, amount)
+	if err != nil {
+		RespondEphemeral(i, T(LocaleFor(i), "poker.cant", ErrorIn(LocaleFor(i), err)))
+		return
+	}
+	table.LastAction = message
+	table.saveStacks()
+	table.startTurnTimer()
+
+	// The modal isn't attached to the table message, so the table message is edited directly
+	if i.Type == discordgo.InteractionModalSubmit {
+		table.editTableMessage()
+		// Acknowledging the modal without leaving a message
+		_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredMessageUpdate,
+		})
+		return
+	}
+
+	table.respondWithTable(i)
+
+}
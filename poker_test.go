@@ -0,0 +1,167 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// pokerCards Returns the cards in short notation, separated by spaces, e.g. "AS 10h Kd"
+func pokerCards(t *testing.T, notation string) []Card {
+
+	var cards []Card
+	for _, field := range strings.Fields(notation) {
+		card, err := ParseCard(field)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cards = append(cards, card)
+	}
+
+	return cards
+
+}
+
+func TestPokerPots(t *testing.T) {
+
+	type seat struct {
+		committed int
+		folded    bool
+	}
+	type pot struct {
+		amount   int
+		eligible []int
+	}
+
+	tests := []struct {
+		name  string
+		seats []seat
+		want  []pot
+	}{
+		{"everyone in for the same", []seat{{10, false}, {10, false}, {10, false}}, []pot{{30, []int{0, 1, 2}}}},
+		{"short all in", []seat{{5, false}, {20, false}, {20, false}}, []pot{{15, []int{0, 1, 2}}, {30, []int{1, 2}}}},
+		{"folded chips go in the pot", []seat{{10, false}, {10, false}, {4, true}}, []pot{{24, []int{0, 1}}}},
+		{"folded chips above the all in", []seat{{5, false}, {10, false}, {10, true}}, []pot{{15, []int{0, 1}}, {10, []int{1}}}},
+		{"two all ins", []seat{{5, false}, {10, false}, {30, false}, {30, false}}, []pot{{20, []int{0, 1, 2, 3}}, {15, []int{1, 2, 3}}, {40, []int{2, 3}}}},
+	}
+
+	for _, test := range tests {
+		table := NewPokerTable("", 100, 1)
+		for i, s := range test.seats {
+			table.Seats = append(table.Seats, &PokerSeat{Username: string(rune('A' + i)), Committed: s.committed, InHand: true, Folded: s.folded})
+		}
+
+		got := table.Pots()
+		if len(got) != len(test.want) {
+			t.Errorf("%s: got %d pots, want %d", test.name, len(got), len(test.want))
+			continue
+		}
+		for i, want := range test.want {
+			var eligible []int
+			for _, seat := range got[i].Eligible {
+				eligible = append(eligible, slices.Index(table.Seats, seat))
+			}
+			if got[i].Amount != want.amount || !slices.Equal(eligible, want.eligible) {
+				t.Errorf("%s: pot %d got %d for %v, want %d for %v", test.name, i, got[i].Amount, eligible, want.amount, want.eligible)
+			}
+		}
+	}
+
+}
+
+func TestPokerFinishHand(t *testing.T) {
+
+	defer func(config Configuration) { Config = config }(Config)
+
+	type seat struct {
+		hole      string
+		committed int
+		folded    bool
+	}
+
+	tests := []struct {
+		name        string
+		board       string
+		seats       []seat
+		rakePercent float64
+		rakeCap     int
+		want        []int
+	}{
+		{"best hand wins", "2C 7D 9H JS KD", []seat{{"AS AD", 10, false}, {"3C 4C", 10, false}}, 0, 0, []int{20, 0}},
+		{"split pot with an odd chip", "AS KS QS JS 10S", []seat{{"2C 3D", 10, false}, {"4H 5H", 10, false}, {"6C 8D", 1, true}}, 0, 0, []int{10, 11, 0}},
+		{"short all in wins the main pot", "2C 7D 9H JS KD", []seat{{"KS KH", 5, false}, {"AS AD", 20, false}, {"3C 4C", 20, false}}, 0, 0, []int{15, 30, 0}},
+		{"rake", "2C 7D 9H JS KD", []seat{{"AS AD", 50, false}, {"3C 4C", 50, false}}, 5, 0, []int{95, 0}},
+		{"rake cap", "2C 7D 9H JS KD", []seat{{"AS AD", 50, false}, {"3C 4C", 50, false}}, 10, 3, []int{97, 0}},
+		{"rake comes out of the main pot first", "2C 7D 9H JS KD", []seat{{"KS KH", 5, false}, {"AS AD", 20, false}, {"3C 4C", 20, false}}, 10, 0, []int{11, 30, 0}},
+		{"no flop, no drop", "", []seat{{"AS AD", 50, false}, {"3C 4C", 50, true}}, 5, 0, []int{100, 0}},
+	}
+
+	for _, test := range tests {
+		Config.PokerRakePercent = test.rakePercent
+		Config.PokerRakeCap = test.rakeCap
+
+		table := NewPokerTable("", 100, 1)
+		table.InHand = true
+		table.Button = 0
+		table.Board = pokerCards(t, test.board)
+		for i, s := range test.seats {
+			table.Seats = append(table.Seats, &PokerSeat{
+				Username:  string(rune('A' + i)),
+				Hole:      pokerCards(t, s.hole),
+				Committed: s.committed,
+				InHand:    true,
+				Folded:    s.folded,
+			})
+		}
+
+		table.finishHand()
+
+		var stacks []int
+		for _, seat := range table.Seats {
+			stacks = append(stacks, seat.Stack)
+		}
+		if !slices.Equal(stacks, test.want) {
+			t.Errorf("%s: got stacks %v, want %v", test.name, stacks, test.want)
+		}
+		if table.InHand {
+			t.Errorf("%s: the hand is still being played", test.name)
+		}
+	}
+
+}
+
+func TestPokerShortAllInDoesNotReopenBetting(t *testing.T) {
+
+	table := NewPokerTable("", 100, 5)
+	table.InHand = true
+	table.Deck = NewShoe(1)
+	table.CurrentBet = 10
+	table.MinRaise = 10
+	table.Seats = []*PokerSeat{
+		{Username: "A", Stack: 90, Bet: 10, Committed: 10, InHand: true, Acted: true},
+		{Username: "B", Stack: 90, Bet: 10, Committed: 10, InHand: true, Acted: true},
+		{Username: "C", Stack: 15, InHand: true},
+	}
+	table.Turn = 2
+
+	if _, err := table.Act("C", PokerAllIn, 0); err != nil {
+		t.Fatal(err)
+	}
+	if table.CurrentBet != 15 || table.MinRaise != 10 {
+		t.Fatalf("got a bet of %d with a min raise of %d, want 15 and 10", table.CurrentBet, table.MinRaise)
+	}
+	if table.Turn != 0 {
+		t.Fatalf("got turn %d, want 0", table.Turn)
+	}
+
+	if _, err := table.Act("A", PokerRaise, 40); err == nil {
+		t.Error("a player who had acted could raise after a short all in")
+	}
+	if _, err := table.Act("A", PokerCall, 0); err != nil {
+		t.Fatal(err)
+	}
+	if table.Seats[0].Bet != 15 {
+		t.Errorf("got a bet of %d after calling, want 15", table.Seats[0].Bet)
+	}
+
+}
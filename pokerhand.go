@@ -0,0 +1,169 @@
+// This file evaluates poker hands. It finds the best five card hand out of any number of cards, and compares hands
+// so they can be ranked at a showdown or paid from a paytable.
+package main
+
+import (
+	"slices"
+	"sort"
+)
+
+// PokerCategory The category of a five card poker hand, from lowest to highest
+type PokerCategory int
+
+const (
+	HighCard PokerCategory = iota
+	OnePair
+	TwoPair
+	ThreeOfAKind
+	Straight
+	Flush
+	FullHouse
+	FourOfAKind
+	StraightFlush
+	RoyalFlush
+)
+
+// Implementing the stringer interface for PokerCategory
+func (c PokerCategory) String() string {
+	return c.StringIn(DefaultLocale)
+}
+
+// StringIn Returns the name of the category in the given language
+func (c PokerCategory) StringIn(locale string) string {
+	return T(locale, []string{
+		"poker.high_card", "poker.one_pair", "poker.two_pair", "poker.three_of_a_kind", "poker.straight", "poker.flush",
+		"poker.full_house", "poker.four_of_a_kind", "poker.straight_flush", "poker.royal_flush",
+	}[c])
+}
+
+// PokerHand A five card poker hand that has been evaluated
+type PokerHand struct {
+	Category PokerCategory
+	// Tiebreak are the ranks that decide between hands of the same category, most important first
+	Tiebreak []int
+	Cards    []Card
+}
+
+// Implementing the stringer interface for PokerHand
+func (h PokerHand) String() string {
+	return h.StringIn(DefaultLocale)
+}
+
+// StringIn Describes the hand in the given language
+func (h PokerHand) StringIn(locale string) string {
+	return h.Category.StringIn(locale) + " (" + RenderCards(h.Cards) + ")"
+}
+
+// Compare Returns 1 if the hand beats the other hand, -1 if it loses to it, and 0 if they tie
+func (h PokerHand) Compare(other PokerHand) int {
+
+	if h.Category != other.Category {
+		if h.Category > other.Category {
+			return 1
+		}
+		return -1
+	}
+
+	return slices.Compare(h.Tiebreak, other.Tiebreak)
+
+}
+
+// EvaluatePokerHand Works out the category and tiebreak ranks of exactly five cards
+func EvaluatePokerHand(cards []Card) PokerHand {
+
+	hand := PokerHand{Cards: slices.Clone(cards)}
+
+	// Counting how many of each rank there are
+	counts := make(map[int]int)
+	flush := true
+	for _, card := range cards {
+		counts[card.Rank.High()]++
+		if card.Suit != cards[0].Suit {
+			flush = false
+		}
+	}
+
+	// Ordering the ranks by how many there are, then by the rank itself, so pairs come before kickers
+	var groups []int
+	for rank := range counts {
+		groups = append(groups, rank)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if counts[groups[i]] != counts[groups[j]] {
+			return counts[groups[i]] > counts[groups[j]]
+		}
+		return groups[i] > groups[j]
+	})
+	hand.Tiebreak = groups
+
+	// Five different ranks in a row make a straight. An ace can also be low, in a five high straight.
+	straight := false
+	if len(groups) == 5 {
+		if groups[0]-groups[4] == 4 {
+			straight = true
+		} else if slices.Equal(groups, []int{14, 5, 4, 3, 2}) {
+			straight = true
+			hand.Tiebreak = []int{5, 4, 3, 2, 1}
+		}
+	}
+
+	switch {
+	case straight && flush && hand.Tiebreak[0] == 14:
+		hand.Category = RoyalFlush
+	case straight && flush:
+		hand.Category = StraightFlush
+	case counts[groups[0]] == 4:
+		hand.Category = FourOfAKind
+	case counts[groups[0]] == 3 && counts[groups[1]] == 2:
+		hand.Category = FullHouse
+	case flush:
+		hand.Category = Flush
+	case straight:
+		hand.Category = Straight
+	case counts[groups[0]] == 3:
+		hand.Category = ThreeOfAKind
+	case counts[groups[0]] == 2 && counts[groups[1]] == 2:
+		hand.Category = TwoPair
+	case counts[groups[0]] == 2:
+		hand.Category = OnePair
+	default:
+		hand.Category = HighCard
+	}
+
+	return hand
+
+}
+
+// BestPokerHand Returns the best five card hand that can be made from the cards, e.g. two hole cards and five on the board
+func BestPokerHand(cards []Card) PokerHand {
+
+	if len(cards) <= 5 {
+		return EvaluatePokerHand(cards)
+	}
+
+	var best PokerHand
+	first := true
+	chosen := make([]Card, 0, 5)
+
+	// Trying every combination of five of the cards
+	var choose func(start int)
+	choose = func(start int) {
+		if len(chosen) == 5 {
+			hand := EvaluatePokerHand(chosen)
+			if first || hand.Compare(best) > 0 {
+				best = hand
+				first = false
+			}
+			return
+		}
+		for i := start; i <= len(cards)-(5-len(chosen)); i++ {
+			chosen = append(chosen, cards[i])
+			choose(i + 1)
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	choose(0)
+
+	return best
+
+}
@@ -0,0 +1,34 @@
+// This file holds the random number generator used by the games, so it can be swapped out in one place.
+package main
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RandomSource Is anything that can pick a random number in [0, n)
+type RandomSource interface {
+	Intn(n int) int
+}
+
+// lockedRand wraps a rand.Rand with a mutex, since games are played in many goroutines at once
+type lockedRand struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// Intn Returns a random number in [0, n)
+func (l *lockedRand) Intn(n int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rng.Intn(n)
+}
+
+// NewLockedRand Creates a RandomSource that is safe to use from many goroutines, seeded with the given seed
+func NewLockedRand(seed int64) RandomSource {
+	return &lockedRand{rng: rand.New(rand.NewSource(seed))}
+}
+
+// RNG The random number generator used for shuffling cards, spinning wheels and rolling dice
+var RNG = NewLockedRand(time.Now().UnixNano())
@@ -0,0 +1,87 @@
+// This file handles the daily and hourly chip rewards. Each can be claimed once per cooldown, and claiming the daily
+// reward on consecutive days builds a streak that adds bonus chips. When each player last claimed is saved, so the
+// cooldowns carry over when the bot restarts.
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Kinds of reward, as they are saved in the database
+const (
+	RewardDaily  = "daily"
+	RewardHourly = "hourly"
+)
+
+// Cooldowns between claims of each reward. A daily streak carries on if the next daily reward is claimed within
+// DailyStreakWindow of the last, so players have a whole day to claim it once the cooldown is up.
+const (
+	DailyCooldown     = 24 * time.Hour
+	DailyStreakWindow = 48 * time.Hour
+	HourlyCooldown    = time.Hour
+)
+
+// RewardClaim The result of trying to claim a reward
+type RewardClaim struct {
+	// Claimed is false if the reward is still on cooldown
+	Claimed bool
+	Chips   int
+	// Streak is the number of consecutive claims, including this one
+	Streak int
+	// Next is when the reward can be claimed again
+	Next time.Time
+}
+
+// DailyRewardChips Returns the chips the daily reward pays on a streak. Each day after the first adds the streak bonus,
+// up to the configured number of days.
+func DailyRewardChips(streak int) int {
+	bonusDays := min(streak-1, Config.DailyStreakMaxDays)
+	return Config.DailyReward + bonusDays*Config.DailyStreakBonus
+}
+
+// HourlyRewardChips Returns the chips the hourly reward pays. It has no streak.
+func HourlyRewardChips(int) int {
+	return Config.HourlyReward
+}
+
+// RewardCommand handles the /daily and /hourly commands, giving the player their chips if the reward is off cooldown
+func RewardCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	locale := LocaleFor(i)
+	player := dba.FindPlayer(i.Member.User.Username)
+
+	kind := i.ApplicationCommandData().Name
+
+	var claim RewardClaim
+	switch kind {
+	case RewardDaily:
+		claim = dba.ClaimReward(&player, RewardDaily, DailyCooldown, DailyStreakWindow, time.Now(), DailyRewardChips)
+	default:
+		claim = dba.ClaimReward(&player, RewardHourly, HourlyCooldown, HourlyCooldown, time.Now(), HourlyRewardChips)
+	}
+
+	if !claim.Claimed {
+		RespondEphemeral(i, T(locale, "reward.cooldown."+kind, claim.Next.Unix()))
+		return
+	}
+
+	message := T(locale, "reward.claimed."+kind, claim.Chips)
+	if kind == RewardDaily && claim.Streak > 1 {
+		message += T(locale, "reward.streak", claim.Streak, DailyRewardChips(claim.Streak)-Config.DailyReward)
+	}
+	message += T(locale, "net.total", player.Chips)
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: message,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
@@ -0,0 +1,450 @@
+// This file implements roulette, on either a European (single zero) or American (double zero) wheel.
+// Bets are written as "<type> [numbers] <amount>", and several can be placed on one spin by separating them with commas.
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// DoubleZero is how the 00 pocket on an American wheel is stored, since it can't be told apart from 0 as an int
+const DoubleZero = 37
+
+// Wheel types
+const (
+	EuropeanWheel = "european"
+	AmericanWheel = "american"
+)
+
+// redNumbers are the red pockets on the wheel. Every other number except the zeroes is black.
+var redNumbers = []int{1, 3, 5, 7, 9, 12, 14, 16, 18, 19, 21, 23, 25, 27, 30, 32, 34, 36}
+
+// RouletteBet A single bet on a spin of the wheel
+type RouletteBet struct {
+	Type    string
+	Numbers []int
+	Amount  int
+}
+
+// Payout Returns the number of chips won for every chip bet if the bet wins.
+// The payouts come from 36 divided by the numbers covered, so a straight pays 35 to 1 and red pays 1 to 1.
+func (b RouletteBet) Payout() int {
+	return 36/len(b.Numbers) - 1
+}
+
+// Wins Returns whether the bet wins when the ball lands on the pocket
+func (b RouletteBet) Wins(pocket int) bool {
+	return slices.Contains(b.Numbers, pocket)
+}
+
+// Implementing the stringer interface for RouletteBet
+func (b RouletteBet) String() string {
+	return b.StringIn(DefaultLocale)
+}
+
+// StringIn Describes the bet in the given language. The bet types are left as they're typed in the command.
+func (b RouletteBet) StringIn(locale string) string {
+
+	// Outside bets are described by their type, inside bets also list their numbers
+	switch b.Type {
+	case "red", "black", "odd", "even", "low", "high":
+		return T(locale, "roulette.bet", b.Type, b.Amount)
+	case "dozen", "column":
+		return T(locale, "roulette.bet_on", b.Type, T(locale, fmt.Sprintf("roulette.ordinal.%d", which(b.Numbers))), b.Amount)
+	}
+
+	numbers := make([]string, len(b.Numbers))
+	for i, n := range b.Numbers {
+		numbers[i] = PocketName(n)
+	}
+
+	return T(locale, "roulette.bet_on", b.Type, strings.Join(numbers, "-"), b.Amount)
+
+}
+
+// which Returns which dozen or column the numbers are, from 1 to 3
+func which(numbers []int) int {
+
+	var which int
+	if numbers[1]-numbers[0] == 1 {
+		// Dozens are consecutive numbers
+		which = (numbers[0]-1)/12 + 1
+	} else {
+		// Columns go up in threes
+		which = numbers[0]
+	}
+
+	return which
+
+}
+
+// PocketName Returns the name of a pocket on the wheel
+func PocketName(pocket int) string {
+	if pocket == DoubleZero {
+		return "00"
+	}
+	return strconv.Itoa(pocket)
+}
+
+// PocketColour Returns the colour of a pocket on the wheel
+func PocketColour(pocket int) string {
+	switch {
+	case pocket == 0 || pocket == DoubleZero:
+		return "green"
+	case slices.Contains(redNumbers, pocket):
+		return "red"
+	}
+	return "black"
+}
+
+// PocketLanding Returns the message saying which pocket the ball landed on, in the given language
+func PocketLanding(locale string, pocket int) string {
+	return T(locale, "roulette.lands", T(locale, "roulette.colour."+PocketColour(pocket)), PocketName(pocket))
+}
+
+// SpinWheel Returns the pocket the ball lands on for the type of wheel
+func SpinWheel(wheel string) int {
+	if wheel == AmericanWheel {
+		// 38 pockets, with 37 being 00
+		return RNG.Intn(38)
+	}
+	return RNG.Intn(37)
+}
+
+// numberRange Returns the numbers from start to end, going up by step
+func numberRange(start int, end int, step int) []int {
+	var numbers []int
+	for n := start; n <= end; n += step {
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
+
+// parsePocket Parses a single pocket number, allowing 00 on American wheels
+func parsePocket(s string, wheel string) (int, error) {
+
+	if s == "00" {
+		if wheel != AmericanWheel {
+			return 0, NewLocalizedError("roulette.error.double_zero")
+		}
+		return DoubleZero, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 36 {
+		return 0, NewLocalizedError("roulette.error.pocket", s)
+	}
+
+	return n, nil
+
+}
+
+// parsePockets Parses a list of pocket numbers separated by dashes, e.g. "17-20"
+func parsePockets(s string, wheel string) ([]int, error) {
+
+	var numbers []int
+	for _, part := range strings.Split(s, "-") {
+		n, err := parsePocket(part, wheel)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, n)
+	}
+
+	slices.Sort(numbers)
+
+	return numbers, nil
+
+}
+
+// validSplit Returns whether two numbers are next to each other on the table layout
+func validSplit(numbers []int, wheel string) bool {
+
+	a, b := numbers[0], numbers[1]
+
+	// Splits with the zeroes. 0 and 00 are next to each other on the American layout.
+	if a == 0 && b == DoubleZero {
+		return wheel == AmericanWheel
+	}
+	if a == 0 {
+		if wheel == AmericanWheel {
+			return b == 1 || b == 2
+		}
+		return b >= 1 && b <= 3
+	}
+	if b == DoubleZero {
+		return a == 2 || a == 3
+	}
+
+	// Next to each other in the same row, or in the same column
+	return (b-a == 1 && a%3 != 0) || b-a == 3
+
+}
+
+// ParseRouletteBet Parses a single bet, e.g. "red 10", "straight 17 5" or "corner 1-2-4-5 5".
+// The amount can't be more than maxAmount, which should be the player's balance.
+func ParseRouletteBet(spec string, wheel string, maxAmount int) (RouletteBet, error) {
+
+	fields := strings.Fields(strings.ToLower(spec))
+	if len(fields) < 2 {
+		return RouletteBet{}, NewLocalizedError("roulette.error.too_short", spec)
+	}
+
+	bet := RouletteBet{Type: fields[0]}
+
+	// The amount is always the last part of the bet
+	amount, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || amount < MinChips {
+		return RouletteBet{}, NewLocalizedError("roulette.error.amount", spec)
+	}
+	if amount > maxAmount {
+		return RouletteBet{}, NewLocalizedError("roulette.error.too_many", spec)
+	}
+	bet.Amount = amount
+
+	// Outside bets don't take any numbers
+	outside := map[string][]int{
+		"red":   redNumbers,
+		"black": {2, 4, 6, 8, 10, 11, 13, 15, 17, 20, 22, 24, 26, 28, 29, 31, 33, 35},
+		"odd":   numberRange(1, 36, 2),
+		"even":  numberRange(2, 36, 2),
+		"low":   numberRange(1, 18, 1),
+		"high":  numberRange(19, 36, 1),
+	}
+	if numbers, ok := outside[bet.Type]; ok {
+		if len(fields) != 2 {
+			return RouletteBet{}, NewLocalizedError("roulette.error.outside", spec)
+		}
+		bet.Numbers = numbers
+		return bet, nil
+	}
+
+	if len(fields) != 3 {
+		return RouletteBet{}, NewLocalizedError("roulette.error.inside", spec)
+	}
+	selection := fields[1]
+
+	switch bet.Type {
+	case "dozen", "column":
+		which, err := strconv.Atoi(selection)
+		if err != nil || which < 1 || which > 3 {
+			return RouletteBet{}, NewLocalizedError("roulette.error.which", spec, bet.Type)
+		}
+		if bet.Type == "dozen" {
+			bet.Numbers = numberRange((which-1)*12+1, which*12, 1)
+		} else {
+			bet.Numbers = numberRange(which, 36, 3)
+		}
+		return bet, nil
+	}
+
+	numbers, err := parsePockets(selection, wheel)
+	if err != nil {
+		return RouletteBet{}, err
+	}
+
+	switch bet.Type {
+	case "straight":
+		if len(numbers) != 1 {
+			return RouletteBet{}, NewLocalizedError("roulette.error.straight", spec)
+		}
+	case "split":
+		if len(numbers) != 2 || !validSplit(numbers, wheel) {
+			return RouletteBet{}, NewLocalizedError("roulette.error.split", spec)
+		}
+	case "street":
+		// A street is a row of three, and can be given by any number in it or the whole row
+		if numbers[0] == 0 || numbers[len(numbers)-1] == DoubleZero {
+			return RouletteBet{}, NewLocalizedError("roulette.error.zero", spec)
+		}
+		start := (numbers[0]-1)/3*3 + 1
+		street := numberRange(start, start+2, 1)
+		if len(numbers) != 1 && !slices.Equal(numbers, street) {
+			return RouletteBet{}, NewLocalizedError("roulette.error.street", spec)
+		}
+		numbers = street
+	case "corner":
+		// A corner is four numbers in a square, like 1-2-4-5
+		a := numbers[0]
+		if len(numbers) != 4 || a == 0 || a%3 == 0 || !slices.Equal(numbers, []int{a, a + 1, a + 3, a + 4}) {
+			return RouletteBet{}, NewLocalizedError("roulette.error.corner", spec)
+		}
+	case "line":
+		// A line is two rows next to each other, and can be given by its first number or the first and last numbers
+		a := numbers[0]
+		if a == 0 || numbers[len(numbers)-1] == DoubleZero || (a-1)%3 != 0 || a > 31 ||
+			(len(numbers) != 1 && !slices.Equal(numbers, []int{a, a + 5})) {
+			return RouletteBet{}, NewLocalizedError("roulette.error.line", spec)
+		}
+		numbers = numberRange(a, a+5, 1)
+	default:
+		return RouletteBet{}, NewLocalizedError("roulette.error.type", bet.Type)
+	}
+
+	bet.Numbers = numbers
+	return bet, nil
+
+}
+
+// ParseRouletteBets Parses several bets separated by commas. No single bet can be more than maxAmount.
+func ParseRouletteBets(specs string, wheel string, maxAmount int) ([]RouletteBet, error) {
+
+	var bets []RouletteBet
+	for _, spec := range strings.Split(specs, ",") {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		bet, err := ParseRouletteBet(spec, wheel, maxAmount)
+		if err != nil {
+			return nil, err
+		}
+		bets = append(bets, bet)
+	}
+
+	if len(bets) == 0 {
+		return nil, NewLocalizedError("roulette.error.no_bets")
+	}
+
+	return bets, nil
+
+}
+
+// errTooManyChips is returned when adding up or paying out bets would overflow
+var errTooManyChips = NewLocalizedError("roulette.error.overflow")
+
+// checkedAdd Returns a + b, and false if the sum overflows
+func checkedAdd(a int, b int) (int, bool) {
+	if (b > 0 && a > math.MaxInt-b) || (b < 0 && a < math.MinInt-b) {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// checkedMul Returns a * b for amounts of chips, which can't be negative, and false if the product overflows
+func checkedMul(a int, b int) (int, bool) {
+	if a < 0 || b < 0 || (b != 0 && a > math.MaxInt/b) {
+		return 0, false
+	}
+	return a * b, true
+}
+
+// TotalWager Returns the total chips bet across all the bets, or an error if the total overflows
+func TotalWager(bets []RouletteBet) (int, error) {
+	total := 0
+	for _, bet := range bets {
+		var ok bool
+		if total, ok = checkedAdd(total, bet.Amount); !ok {
+			return 0, errTooManyChips
+		}
+	}
+	return total, nil
+}
+
+// SettleRouletteBets Returns the net chips won or lost by the bets when the ball lands on the pocket, and a line for each bet
+// in the given language. Returns an error if the winnings overflow.
+func SettleRouletteBets(locale string, bets []RouletteBet, pocket int) (int, string, error) {
+
+	var sb strings.Builder
+	net := 0
+
+	for _, bet := range bets {
+		if bet.Wins(pocket) {
+			won, ok := checkedMul(bet.Amount, bet.Payout())
+			if ok {
+				net, ok = checkedAdd(net, won)
+			}
+			if !ok {
+				return 0, "", errTooManyChips
+			}
+			sb.WriteString(T(locale, "roulette.bet_wins", bet.StringIn(locale), won))
+		} else {
+			var ok bool
+			if net, ok = checkedAdd(net, -bet.Amount); !ok {
+				return 0, "", errTooManyChips
+			}
+			sb.WriteString(T(locale, "roulette.bet_loses", bet.StringIn(locale)))
+		}
+	}
+
+	return net, sb.String(), nil
+
+}
+
+// RouletteCommand handles the /roulette command, spinning the wheel once for all the player's bets
+func RouletteCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	// Getting options and storing in map
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	wheel := EuropeanWheel
+	if opt, ok := optionMap["wheel"]; ok {
+		wheel = opt.StringValue()
+	}
+
+	locale := LocaleFor(i)
+	player := dba.FindPlayer(i.Member.User.Username)
+
+	bets, err := ParseRouletteBets(optionMap["bets"].StringValue(), wheel, player.Chips)
+	if err != nil {
+		RespondEphemeral(i, T(locale, "roulette.bad_bets", ErrorIn(locale, err)))
+		return
+	}
+
+	// Checking the player has enough chips for all the bets
+	total, err := TotalWager(bets)
+	if err != nil {
+		RespondEphemeral(i, T(locale, "roulette.cant_place", ErrorIn(locale, err)))
+		return
+	}
+	if player.Chips < total {
+		RespondEphemeral(i, T(locale, "roulette.not_enough_chips", total, player.Chips))
+		return
+	}
+
+	// Holding the bets in escrow while the wheel spins, so the spin is settled against them rather than the chips the
+	// player had when the command came in
+	escrowID, err := dba.EscrowChips(&player, total)
+	if err != nil {
+		RespondEphemeral(i, T(locale, "roulette.not_enough_chips", total, dba.GetChipTotal(player.Username)))
+		return
+	}
+
+	pocket := SpinWheel(wheel)
+	net, results, err := SettleRouletteBets(locale, bets, pocket)
+	if err != nil {
+		dba.RefundEscrow(escrowID)
+		RespondEphemeral(i, T(locale, "roulette.cant_settle", ErrorIn(locale, err)))
+		return
+	}
+
+	message := T(locale, "roulette.spins", player.Username, T(locale, "roulette.wheel."+wheel)) + PocketLanding(locale, pocket)
+	message += results
+	message += "\n"
+	if net > 0 {
+		message += T(locale, "roulette.player_wins", player.Username)
+	} else if net < 0 {
+		message += T(locale, "roulette.house_wins")
+	} else {
+		message += T(locale, "roulette.even")
+	}
+
+	settled, _ := dba.SettleEscrow(escrowID, &player, locale, net)
+	message += settled
+
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: message,
+		},
+	})
+
+}
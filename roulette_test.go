@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRoulettePayouts(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		wheel   string
+		bets    string
+		pocket  int
+		wantNet int
+	}{
+		{"straight", EuropeanWheel, "straight 17 10", 17, 350},
+		{"straight misses", EuropeanWheel, "straight 17 10", 18, -10},
+		{"straight on 0", EuropeanWheel, "straight 0 10", 0, 350},
+		{"straight on 0", AmericanWheel, "straight 0 10", 0, 350},
+		{"straight on 00", AmericanWheel, "straight 00 10", DoubleZero, 350},
+		{"straight on 0 misses 00", AmericanWheel, "straight 0 10", DoubleZero, -10},
+		{"split", EuropeanWheel, "split 17-20 10", 20, 170},
+		{"split", AmericanWheel, "split 17-18 10", 17, 170},
+		{"split with 0", EuropeanWheel, "split 0-3 10", 0, 170},
+		{"split 0 and 00", AmericanWheel, "split 0-00 10", DoubleZero, 170},
+		{"split with 00", AmericanWheel, "split 3-00 10", 3, 170},
+		{"street", EuropeanWheel, "street 5 10", 6, 110},
+		{"street", AmericanWheel, "street 4-5-6 10", 4, 110},
+		{"corner", EuropeanWheel, "corner 1-2-4-5 10", 5, 80},
+		{"corner", AmericanWheel, "corner 32-33-35-36 10", 32, 80},
+		{"line", EuropeanWheel, "line 1 10", 6, 50},
+		{"line", AmericanWheel, "line 31-36 10", 36, 50},
+		{"dozen", EuropeanWheel, "dozen 2 10", 24, 20},
+		{"dozen", AmericanWheel, "dozen 3 10", 25, 20},
+		{"column", EuropeanWheel, "column 1 10", 34, 20},
+		{"column", AmericanWheel, "column 3 10", 3, 20},
+		{"red", EuropeanWheel, "red 10", 1, 10},
+		{"black", AmericanWheel, "black 10", 2, 10},
+		{"odd", EuropeanWheel, "odd 10", 35, 10},
+		{"even", AmericanWheel, "even 10", 36, 10},
+		{"low", EuropeanWheel, "low 10", 18, 10},
+		{"high", AmericanWheel, "high 10", 19, 10},
+		{"outside bets lose on 0", EuropeanWheel, "red 10, black 10, odd 10, even 10, low 10, high 10", 0, -60},
+		{"outside bets lose on 00", AmericanWheel, "red 10, black 10, odd 10, even 10, low 10, high 10", DoubleZero, -60},
+		{"dozens and columns lose on 0", EuropeanWheel, "dozen 1 10, column 1 10", 0, -20},
+		{"dozens and columns lose on 00", AmericanWheel, "dozen 1 10, column 1 10", DoubleZero, -20},
+		{"several bets", EuropeanWheel, "straight 7 5, red 10, dozen 3 10", 7, 175 + 10 - 10},
+	}
+
+	for _, test := range tests {
+		bets, err := ParseRouletteBets(test.bets, test.wheel, 1000)
+		if err != nil {
+			t.Errorf("%s on the %s wheel: %v", test.name, test.wheel, err)
+			continue
+		}
+		net, _, err := SettleRouletteBets(DefaultLocale, bets, test.pocket)
+		if err != nil {
+			t.Errorf("%s on the %s wheel: %v", test.name, test.wheel, err)
+			continue
+		}
+		if net != test.wantNet {
+			t.Errorf("%s on the %s wheel: got a net of %d, want %d", test.name, test.wheel, net, test.wantNet)
+		}
+	}
+
+}
+
+func TestRouletteRejects(t *testing.T) {
+
+	tests := []struct {
+		wheel   string
+		bet     string
+		wantKey string
+	}{
+		{EuropeanWheel, "split 17-19 10", "roulette.error.split"},
+		{EuropeanWheel, "split 3-4 10", "roulette.error.split"},
+		{EuropeanWheel, "split 1-2-3 10", "roulette.error.split"},
+		{EuropeanWheel, "split 17 10", "roulette.error.split"},
+		{EuropeanWheel, "split 0-00 10", "roulette.error.double_zero"},
+		{AmericanWheel, "split 0-3 10", "roulette.error.split"},
+		{AmericanWheel, "split 1-00 10", "roulette.error.split"},
+		{EuropeanWheel, "corner 3-4-6-7 10", "roulette.error.corner"},
+		{EuropeanWheel, "corner 1-2-3-4 10", "roulette.error.corner"},
+		{EuropeanWheel, "corner 0-1-2-3 10", "roulette.error.corner"},
+		{EuropeanWheel, "corner 1-2-4 10", "roulette.error.corner"},
+		{AmericanWheel, "corner 2-3-00-5 10", "roulette.error.corner"},
+		{EuropeanWheel, "straight 37 10", "roulette.error.pocket"},
+		{EuropeanWheel, "straight 00 10", "roulette.error.double_zero"},
+		{EuropeanWheel, "dozen 4 10", "roulette.error.which"},
+		{EuropeanWheel, "red 0", "roulette.error.amount"},
+		{EuropeanWheel, "red 1001", "roulette.error.too_many"},
+		{EuropeanWheel, "basket 0-1-2 10", "roulette.error.type"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseRouletteBets(test.bet, test.wheel, 1000)
+		var localized *LocalizedError
+		if !errors.As(err, &localized) {
+			t.Errorf("%q on the %s wheel: got %v, want %s", test.bet, test.wheel, err, test.wantKey)
+			continue
+		}
+		if localized.Key != test.wantKey {
+			t.Errorf("%q on the %s wheel: got %s, want %s", test.bet, test.wheel, localized.Key, test.wantKey)
+		}
+	}
+
+}
@@ -0,0 +1,495 @@
+// This file implements the multiplayer roulette table. A table opens a timed betting window in a channel where anyone can
+// place bets, then the wheel is spun once and everyone's bets are settled together.
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Chip amounts players can pick from at the table
+var tableChipAmounts = []int{1, 5, 10, 25, 50, 100}
+
+// The outside bets that can be picked from the menu at the table. Inside bets are typed out instead.
+var tableBetTypes = []string{"red", "black", "odd", "even", "low", "high", "dozen 1", "dozen 2", "dozen 3", "column 1", "column 2", "column 3"}
+
+// tableSelection The bet type and amount a player has picked from the menus, before placing the bet
+type tableSelection struct {
+	Type   string
+	Amount int
+}
+
+// RouletteTable A roulette table with a betting window open in a channel
+type RouletteTable struct {
+	mu sync.Mutex
+
+	ChannelID string
+	MessageID string
+	Wheel     string
+	ClosesAt  time.Time
+	// Locale is the language of the member who opened the table, which the table is shown in
+	Locale string
+	// Closed is set once betting has closed and the table message's menus have been removed
+	Closed bool
+
+	// Bets placed by each player, by username
+	Bets map[string][]RouletteBet
+	// escrows hold the chips for each player's bets until the wheel is spun, by username
+	escrows map[string][]int64
+	// selections are what each player has picked in the menus, by username
+	selections map[string]tableSelection
+}
+
+var (
+	// RouletteTablesMap open roulette tables, by channel ID
+	RouletteTablesMap = make(map[string]*RouletteTable)
+	rouletteTablesMu  sync.Mutex
+)
+
+// FindRouletteTable is a helper function to find the open roulette table in a channel
+func FindRouletteTable(channelID string) *RouletteTable {
+	rouletteTablesMu.Lock()
+	defer rouletteTablesMu.Unlock()
+	return RouletteTablesMap[channelID]
+}
+
+// Content Returns the table message, listing the bets placed so far
+func (t *RouletteTable) Content() string {
+
+	var sb strings.Builder
+
+	wheel := T(t.Locale, "roulette.wheel."+t.Wheel)
+	if t.Closed {
+		sb.WriteString(T(t.Locale, "roulette_table.closed", wheel))
+	} else {
+		sb.WriteString(T(t.Locale, "roulette_table.open", wheel, t.ClosesAt.Unix()))
+	}
+
+	if len(t.Bets) == 0 {
+		sb.WriteString(T(t.Locale, "roulette_table.no_bets"))
+		return sb.String()
+	}
+
+	for _, username := range t.players() {
+		bets := make([]string, len(t.Bets[username]))
+		for i, bet := range t.Bets[username] {
+			bets[i] = bet.StringIn(t.Locale)
+		}
+		sb.WriteString(fmt.Sprintf("**%s**: %s\n", username, strings.Join(bets, ", ")))
+	}
+
+	return sb.String()
+
+}
+
+// players Returns the usernames of everyone with a bet on the table, in alphabetical order
+func (t *RouletteTable) players() []string {
+	var usernames []string
+	for username := range t.Bets {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+// PlaceBet Adds a bet for the player, holding its chips in escrow until the wheel is spun so they can't be spent
+// elsewhere while betting is open. The player passed in is updated too.
+func (t *RouletteTable) PlaceBet(player *Player, bet RouletteBet) error {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Closed || time.Now().After(t.ClosesAt) {
+		return NewLocalizedError("roulette_table.error.closed")
+	}
+
+	if _, err := TotalWager(append(slices.Clone(t.Bets[player.Username]), bet)); err != nil {
+		return err
+	}
+
+	escrowID, err := dba.EscrowChips(player, bet.Amount)
+	if err != nil {
+		return NewLocalizedError("roulette_table.error.not_enough_chips", dba.GetChipTotal(player.Username))
+	}
+
+	t.Bets[player.Username] = append(t.Bets[player.Username], bet)
+	t.escrows[player.Username] = append(t.escrows[player.Username], escrowID)
+
+	return nil
+
+}
+
+// ClearBets Takes the player's bets off the table, giving them back their chips
+func (t *RouletteTable) ClearBets(username string) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, id := range t.escrows[username] {
+		dba.RefundEscrow(id)
+	}
+	delete(t.Bets, username)
+	delete(t.escrows, username)
+
+}
+
+// Spin Spins the wheel and settles every player's bets, updating them in the database. Returns the results message.
+func (t *RouletteTable) Spin() string {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.Bets) == 0 {
+		return T(t.Locale, "roulette_table.no_spin")
+	}
+
+	pocket := SpinWheel(t.Wheel)
+
+	var sb strings.Builder
+	sb.WriteString(T(t.Locale, "roulette_table.spins") + PocketLanding(t.Locale, pocket))
+
+	for _, username := range t.players() {
+
+		// The bets were checked when they were placed, so they can only fail to settle if something is badly wrong
+		net, _, err := SettleRouletteBets(t.Locale, t.Bets[username], pocket)
+		if err != nil {
+			log.Printf("Couldn't settle %s's roulette bets: %v", username, err)
+			for _, id := range t.escrows[username] {
+				dba.RefundEscrow(id)
+			}
+			continue
+		}
+
+		// Settling the net against the bets in escrow, since the player's chips could have changed while betting was
+		// open and anything they won or spent elsewhere has to be kept
+		player := dba.FindPlayer(username)
+		if _, ok := dba.SettleEscrows(t.escrows[username], &player, t.Locale, net); !ok {
+			log.Printf("Couldn't settle %s's roulette bets: their escrow was already released", username)
+			continue
+		}
+
+		sb.WriteString(T(t.Locale, "roulette_table.result", username, net, player.Chips))
+	}
+
+	return sb.String()
+
+}
+
+// rouletteTableComponents Returns the menus and buttons used to bet at the table, in the given language
+func rouletteTableComponents(locale string) []discordgo.MessageComponent {
+
+	typeOptions := make([]discordgo.SelectMenuOption, len(tableBetTypes))
+	for i, betType := range tableBetTypes {
+		label := T(locale, "roulette_table.type."+strings.ReplaceAll(betType, " ", "_"))
+		typeOptions[i] = discordgo.SelectMenuOption{Label: label, Value: betType}
+	}
+
+	amountOptions := make([]discordgo.SelectMenuOption, len(tableChipAmounts))
+	for i, amount := range tableChipAmounts {
+		amountOptions[i] = discordgo.SelectMenuOption{Label: TN(locale, "roulette_table.chips", amount, amount), Value: fmt.Sprint(amount)}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    "roulette-table-type",
+					Placeholder: T(locale, "roulette_table.pick_bet"),
+					Options:     typeOptions,
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    "roulette-table-amount",
+					Placeholder: T(locale, "roulette_table.pick_amount"),
+					Options:     amountOptions,
+				},
+			},
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    T(locale, "roulette_table.place_button"),
+					Style:    discordgo.SuccessButton,
+					CustomID: "roulette-table-place",
+				},
+				discordgo.Button{
+					Label:    T(locale, "roulette_table.inside_button"),
+					Style:    discordgo.PrimaryButton,
+					CustomID: "roulette-table-inside",
+				},
+				discordgo.Button{
+					Label:    T(locale, "roulette_table.clear_button"),
+					Style:    discordgo.DangerButton,
+					CustomID: "roulette-table-clear",
+				},
+			},
+		},
+	}
+
+}
+
+// updateTableMessage Edits the table message to show the latest bets
+func (t *RouletteTable) updateTableMessage() {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Not putting the menus back on the message if betting closed in the meantime
+	if t.Closed {
+		return
+	}
+	content := t.Content()
+
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		Content:    &content,
+		ID:         t.MessageID,
+		Channel:    t.ChannelID,
+		Components: rouletteTableComponents(t.Locale),
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// closeAfterWindow Waits for the betting window to close, then spins the wheel and posts the results.
+// Meant to be run as a goroutine.
+func (t *RouletteTable) closeAfterWindow() {
+
+	time.Sleep(time.Until(t.ClosesAt))
+
+	rouletteTablesMu.Lock()
+	delete(RouletteTablesMap, t.ChannelID)
+	rouletteTablesMu.Unlock()
+
+	// Removing the menus and buttons so no more bets can be placed
+	t.mu.Lock()
+	t.Closed = true
+	content := t.Content()
+	t.mu.Unlock()
+	RemoveComponentsFromMessage(t.ChannelID, t.MessageID, content)
+
+	_, _ = s.ChannelMessageSend(t.ChannelID, t.Spin())
+
+}
+
+// RouletteTableCommand handles the /roulette-table command, opening a betting window in the channel
+func RouletteTableCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	// Getting options and storing in map
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	wheel := EuropeanWheel
+	if opt, ok := optionMap["wheel"]; ok {
+		wheel = opt.StringValue()
+	}
+	seconds := int64(60)
+	if opt, ok := optionMap["seconds"]; ok {
+		seconds = opt.IntValue()
+	}
+
+	table := &RouletteTable{
+		ChannelID:  i.ChannelID,
+		Wheel:      wheel,
+		Locale:     LocaleFor(i),
+		ClosesAt:   time.Now().Add(time.Duration(seconds) * time.Second),
+		Bets:       make(map[string][]RouletteBet),
+		escrows:    make(map[string][]int64),
+		selections: make(map[string]tableSelection),
+	}
+
+	// Only one table can be open in a channel at a time
+	rouletteTablesMu.Lock()
+	if _, ok := RouletteTablesMap[i.ChannelID]; ok {
+		rouletteTablesMu.Unlock()
+		RespondEphemeral(i, T(table.Locale, "roulette_table.already_open"))
+		return
+	}
+	RouletteTablesMap[i.ChannelID] = table
+	rouletteTablesMu.Unlock()
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    table.Content(),
+			Components: rouletteTableComponents(table.Locale),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+	// Getting the table message so it can be edited as bets come in
+	message, err := s.InteractionResponse(i.Interaction)
+	if err != nil {
+		log.Println(err)
+	} else {
+		table.MessageID = message.ID
+	}
+
+	go table.closeAfterWindow()
+
+}
+
+// RouletteTableSelect handles the bet type and amount menus at the table, remembering what the player picked
+func RouletteTableSelect(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	table := FindRouletteTable(i.ChannelID)
+	if table == nil {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	data := i.MessageComponentData()
+	username := i.Member.User.Username
+
+	table.mu.Lock()
+	selection := table.selections[username]
+	if data.CustomID == "roulette-table-type" {
+		selection.Type = data.Values[0]
+	} else {
+		_, _ = fmt.Sscan(data.Values[0], &selection.Amount)
+	}
+	table.selections[username] = selection
+	table.mu.Unlock()
+
+	// Acknowledging without changing the message
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+
+}
+
+// RouletteTablePlace handles the place bet button, placing the bet the player picked from the menus
+func RouletteTablePlace(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	table := FindRouletteTable(i.ChannelID)
+	if table == nil {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	table.mu.Lock()
+	selection := table.selections[i.Member.User.Username]
+	table.mu.Unlock()
+
+	if selection.Type == "" || selection.Amount == 0 {
+		RespondEphemeral(i, T(LocaleFor(i), "roulette_table.pick_first"))
+		return
+	}
+
+	player := dba.FindPlayer(i.Member.User.Username)
+	bet, err := ParseRouletteBet(fmt.Sprintf("%s %d", selection.Type, selection.Amount), table.Wheel, player.Chips)
+	if err != nil {
+		RespondEphemeral(i, T(LocaleFor(i), "roulette_table.cant_place", ErrorIn(LocaleFor(i), err)))
+		return
+	}
+
+	placeTableBet(i, table, bet)
+
+}
+
+// RouletteTableInside handles the inside bet button, showing the player a form to type their bet into
+func RouletteTableInside(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	if FindRouletteTable(i.ChannelID) == nil {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	locale := LocaleFor(i)
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: "roulette-table-modal",
+			Title:    T(locale, "roulette_table.modal_title"),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "bet",
+							Label:       T(locale, "roulette_table.modal_label"),
+							Style:       discordgo.TextInputShort,
+							Placeholder: T(locale, "roulette_table.modal_placeholder"),
+							Required:    true,
+							MaxLength:   100,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// RouletteTableModalSubmit handles an inside bet typed into the form
+func RouletteTableModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	locale := LocaleFor(i)
+	table := FindRouletteTable(i.ChannelID)
+	if table == nil {
+		RespondEphemeral(i, T(locale, "roulette_table.betting_closed"))
+		return
+	}
+
+	spec := i.ModalSubmitData().Components[0].(*discordgo.ActionsRow).Components[0].(*discordgo.TextInput).Value
+
+	player := dba.FindPlayer(i.Member.User.Username)
+	bet, err := ParseRouletteBet(spec, table.Wheel, player.Chips)
+	if err != nil {
+		RespondEphemeral(i, T(locale, "roulette_table.bad_bet", ErrorIn(locale, err)))
+		return
+	}
+
+	placeTableBet(i, table, bet)
+
+}
+
+// RouletteTableClear handles the clear my bets button, removing all of the player's bets from the table
+func RouletteTableClear(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	table := FindRouletteTable(i.ChannelID)
+	if table == nil {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	table.ClearBets(i.Member.User.Username)
+
+	RespondEphemeral(i, T(LocaleFor(i), "roulette_table.cleared"))
+	table.updateTableMessage()
+
+}
+
+// placeTableBet places a bet for the player who sent the interaction, and lets them know if it worked
+func placeTableBet(i *discordgo.InteractionCreate, table *RouletteTable, bet RouletteBet) {
+
+	locale := LocaleFor(i)
+	player := dba.FindPlayer(i.Member.User.Username)
+
+	if err := table.PlaceBet(&player, bet); err != nil {
+		RespondEphemeral(i, T(locale, "roulette_table.cant_place", ErrorIn(locale, err)))
+		return
+	}
+
+	RespondEphemeral(i, T(locale, "roulette_table.placed", bet.StringIn(locale)))
+	table.updateTableMessage()
+
+}
@@ -0,0 +1,344 @@
+// This file implements the slot machine. The reel strips, paylines and paytable are all read from a config file, so the
+// machine can be changed without rebuilding, and its return to player (RTP) can be worked out before it is enabled.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// AnySymbol matches any symbol in a paytable entry
+const AnySymbol = "any"
+
+// SlotsPay A winning combination of symbols on a payline, read left to right
+type SlotsPay struct {
+	Symbols []string
+	// Pays is the number of chips won for each chip bet on the line
+	Pays int
+}
+
+// SlotsConfig The layout of the slot machine, read from the slots config file
+type SlotsConfig struct {
+	Enabled bool
+	// Rows is the number of rows of symbols shown on each reel
+	Rows int
+	// Reels are the symbols on each reel strip, in order
+	Reels [][]string
+	// Symbols are how each symbol is displayed in Discord, usually an emoji
+	Symbols map[string]string
+	// Paylines are the row of each reel a line goes through, e.g. [1, 1, 1] is the middle row
+	Paylines [][]int
+	Paytable []SlotsPay
+	// JackpotSymbol wins the progressive jackpot when it fills a payline. Left empty, the slots can't win the jackpot.
+	JackpotSymbol string
+}
+
+// Slots The slot machine, loaded from the config file on startup
+var Slots SlotsConfig
+
+// LoadSlotsConfig Reads the slot machine layout from a JSON file and checks it is valid
+func LoadSlotsConfig(fileName string) (SlotsConfig, error) {
+
+	var config SlotsConfig
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return config, err
+	}
+
+	if err = json.Unmarshal(data, &config); err != nil {
+		return config, err
+	}
+
+	return config, config.Validate()
+
+}
+
+// Validate Checks the reels, paylines and paytable all fit together
+func (c SlotsConfig) Validate() error {
+
+	if len(c.Reels) == 0 || c.Rows <= 0 {
+		return fmt.Errorf("slots need at least one reel and one row")
+	}
+
+	for i, reel := range c.Reels {
+		if len(reel) < c.Rows {
+			return fmt.Errorf("reel %d has fewer symbols than there are rows", i+1)
+		}
+		for _, symbol := range reel {
+			if _, ok := c.Symbols[symbol]; !ok {
+				return fmt.Errorf("reel %d has symbol %q, which has no display set", i+1, symbol)
+			}
+		}
+	}
+
+	if len(c.Paylines) == 0 {
+		return fmt.Errorf("slots need at least one payline")
+	}
+	for i, line := range c.Paylines {
+		if len(line) != len(c.Reels) {
+			return fmt.Errorf("payline %d doesn't have a row for every reel", i+1)
+		}
+		for _, row := range line {
+			if row < 0 || row >= c.Rows {
+				return fmt.Errorf("payline %d goes through a row that doesn't exist", i+1)
+			}
+		}
+	}
+
+	if _, ok := c.Symbols[c.JackpotSymbol]; !ok && c.JackpotSymbol != "" {
+		return fmt.Errorf("the jackpot symbol %q isn't on the machine", c.JackpotSymbol)
+	}
+
+	for i, pay := range c.Paytable {
+		if len(pay.Symbols) != len(c.Reels) {
+			return fmt.Errorf("paytable entry %d doesn't have a symbol for every reel", i+1)
+		}
+		for _, symbol := range pay.Symbols {
+			if _, ok := c.Symbols[symbol]; !ok && symbol != AnySymbol {
+				return fmt.Errorf("paytable entry %d has symbol %q, which isn't on the machine", i+1, symbol)
+			}
+		}
+	}
+
+	return nil
+
+}
+
+// LinePays Returns the chips won per chip bet for a line of symbols. Only the best matching paytable entry pays.
+func (c SlotsConfig) LinePays(symbols []string) int {
+
+	best := 0
+
+	for _, pay := range c.Paytable {
+		matches := true
+		for i, symbol := range pay.Symbols {
+			if symbol != AnySymbol && symbol != symbols[i] {
+				matches = false
+				break
+			}
+		}
+		if matches && pay.Pays > best {
+			best = pay.Pays
+		}
+	}
+
+	return best
+
+}
+
+// SlotsSpin The result of a spin. Grid holds the symbols showing on each reel, top row first.
+type SlotsSpin struct {
+	Grid [][]string
+	// LineWins are the chips won per chip bet on each payline
+	LineWins []int
+	// Jackpot is whether a payline is filled with the jackpot symbol
+	Jackpot bool
+}
+
+// Spin Spins every reel, and works out what each payline wins
+func (c SlotsConfig) Spin() SlotsSpin {
+
+	spin := SlotsSpin{Grid: make([][]string, len(c.Reels))}
+
+	// Each reel stops at a random position, showing that symbol and the ones below it
+	for i, reel := range c.Reels {
+		stop := RNG.Intn(len(reel))
+		for row := 0; row < c.Rows; row++ {
+			spin.Grid[i] = append(spin.Grid[i], reel[(stop+row)%len(reel)])
+		}
+	}
+
+	for _, line := range c.Paylines {
+		symbols := make([]string, len(line))
+		for reel, row := range line {
+			symbols[reel] = spin.Grid[reel][row]
+		}
+		spin.LineWins = append(spin.LineWins, c.LinePays(symbols))
+
+		if c.JackpotSymbol != "" && !slices.ContainsFunc(symbols, func(symbol string) bool { return symbol != c.JackpotSymbol }) {
+			spin.Jackpot = true
+		}
+	}
+
+	return spin
+
+}
+
+// TotalPays Returns the chips won per chip bet on each line, across all lines
+func (s SlotsSpin) TotalPays() int {
+	total := 0
+	for _, win := range s.LineWins {
+		total += win
+	}
+	return total
+}
+
+// Display Returns the grid of symbols as a message, with only the first revealed reels showing.
+// The rest are shown as still spinning.
+func (c SlotsConfig) Display(spin SlotsSpin, revealed int) string {
+
+	var sb strings.Builder
+
+	for row := 0; row < c.Rows; row++ {
+		for reel := range spin.Grid {
+			if reel < revealed {
+				sb.WriteString(c.Symbols[spin.Grid[reel][row]])
+			} else {
+				sb.WriteString("🔄")
+			}
+			sb.WriteString(" ")
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+
+}
+
+// SlotsReport The payout math for the slot machine, worked out without spinning
+type SlotsReport struct {
+	// RTP is the fraction of chips bet that are paid back on average
+	RTP float64
+	// LineHitRate is the chance of any one payline winning
+	LineHitRate float64
+	// Combinations is the number of equally likely symbol combinations on a payline
+	Combinations int
+}
+
+// Report Works out the RTP of the machine by going through every combination of symbols a payline can show.
+// The progressive jackpot isn't included, since its size depends on how much has been wagered across all the games.
+// Each reel stops at a uniformly random position, so every row of a reel shows each symbol with the same chance,
+// and every payline has the same chance of winning. The RTP for one line is the RTP for the whole machine.
+func (c SlotsConfig) Report() SlotsReport {
+
+	// Counting how many times each symbol appears on each reel
+	counts := make([]map[string]int, len(c.Reels))
+	for i, reel := range c.Reels {
+		counts[i] = make(map[string]int)
+		for _, symbol := range reel {
+			counts[i][symbol]++
+		}
+	}
+
+	report := SlotsReport{Combinations: 1}
+	for _, reel := range c.Reels {
+		report.Combinations *= len(reel)
+	}
+
+	// Going through every combination of distinct symbols, weighted by how many stops show that combination
+	var expected, hits float64
+	symbols := make([]string, len(c.Reels))
+	var walk func(reel int, weight int)
+	walk = func(reel int, weight int) {
+		if reel == len(c.Reels) {
+			pays := c.LinePays(symbols)
+			expected += float64(weight * pays)
+			if pays > 0 {
+				hits += float64(weight)
+			}
+			return
+		}
+		for symbol, count := range counts[reel] {
+			symbols[reel] = symbol
+			walk(reel+1, weight*count)
+		}
+	}
+	walk(0, 1)
+
+	report.RTP = expected / float64(report.Combinations)
+	report.LineHitRate = hits / float64(report.Combinations)
+
+	return report
+
+}
+
+// Implementing the stringer interface for SlotsReport
+func (r SlotsReport) String() string {
+	return fmt.Sprintf("RTP %.2f%%, each payline wins %.2f%% of the time (%d combinations per line)", r.RTP*100, r.LineHitRate*100, r.Combinations)
+}
+
+// SlotsCommand handles the /slots command, spinning the machine and revealing each reel one at a time
+func SlotsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	locale := LocaleFor(i)
+
+	if !Slots.Enabled {
+		RespondEphemeral(i, T(locale, "slots.closed"))
+		return
+	}
+
+	lineBet := int(i.ApplicationCommandData().Options[0].IntValue())
+	totalBet := lineBet * len(Slots.Paylines)
+
+	player := dba.FindPlayer(i.Member.User.Username)
+
+	if player.Chips < totalBet {
+		RespondEphemeral(i, T(locale, "slots.not_enough_chips", lineBet, len(Slots.Paylines), totalBet, player.Chips))
+		return
+	}
+
+	// Holding the bet in escrow and settling the spin against it before the reels are shown, so the chips can't be
+	// spent twice
+	escrowID, err := dba.EscrowChips(&player, totalBet)
+	if err != nil {
+		RespondEphemeral(i, T(locale, "slots.not_enough_chips", lineBet, len(Slots.Paylines), totalBet, dba.GetChipTotal(player.Username)))
+		return
+	}
+
+	spin := Slots.Spin()
+	result, _ := dba.SettleEscrow(escrowID, &player, locale, spin.TotalPays()*lineBet-totalBet)
+	if spin.Jackpot {
+		before := player.Chips
+		result += PayJackpot(&player, locale, JackpotSlots, "jackpot.reason.slots", Slots.Symbols[Slots.JackpotSymbol])
+		dba.AddChips(player.Username, player.Chips-before)
+	}
+
+	title := T(locale, "slots.title", player.Username, lineBet, len(Slots.Paylines))
+
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: title + Slots.Display(spin, 0),
+		},
+	})
+
+	// Revealing the reels one at a time by editing the message
+	for reel := 1; reel <= len(Slots.Reels); reel++ {
+		time.Sleep(time.Second)
+		content := title + Slots.Display(spin, reel)
+		if reel == len(Slots.Reels) {
+			content += "\n" + slotsResult(locale, spin, lineBet) + result
+		}
+		if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &content}); err != nil {
+			log.Println(err)
+		}
+	}
+
+}
+
+// slotsResult Returns a message listing the paylines that won
+func slotsResult(locale string, spin SlotsSpin, lineBet int) string {
+
+	var sb strings.Builder
+
+	for line, win := range spin.LineWins {
+		if win > 0 {
+			sb.WriteString(T(locale, "slots.line_wins", line+1, win*lineBet))
+		}
+	}
+
+	if sb.Len() == 0 {
+		return T(locale, "slots.no_wins")
+	}
+
+	return sb.String()
+
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// testSlots is a small machine whose RTP can be worked out by hand. The first reel shows a 2 in 3 times, the second
+// 1 in 2 times and the third 1 in 4 times, so three a's come up 1 in 12 times and pay 10, and an a on the first reel
+// pays 1 the other 7 in 12 times. That's an RTP of 17/12, winning 2 in 3 times.
+var testSlots = SlotsConfig{
+	Rows:     1,
+	Reels:    [][]string{{"a", "a", "b"}, {"a", "b"}, {"a", "b", "b", "b"}},
+	Symbols:  map[string]string{"a": "🅰️", "b": "🅱️"},
+	Paylines: [][]int{{0, 0, 0}},
+	Paytable: []SlotsPay{
+		{Symbols: []string{"a", "a", "a"}, Pays: 10},
+		{Symbols: []string{"a", AnySymbol, AnySymbol}, Pays: 1},
+	},
+}
+
+func TestSlotsReport(t *testing.T) {
+
+	if err := testSlots.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	report := testSlots.Report()
+	if report.Combinations != 24 {
+		t.Errorf("got %d combinations, want 24", report.Combinations)
+	}
+	if math.Abs(report.RTP-17.0/12) > 1e-9 {
+		t.Errorf("got an RTP of %f, want %f", report.RTP, 17.0/12)
+	}
+	if math.Abs(report.LineHitRate-2.0/3) > 1e-9 {
+		t.Errorf("got a hit rate of %f, want %f", report.LineHitRate, 2.0/3)
+	}
+
+}
+
+func TestSlotsSpinMatchesReport(t *testing.T) {
+
+	// Spinning the machine many times should pay back about what the report works out
+	const spins = 200000
+	paid := 0
+	for i := 0; i < spins; i++ {
+		paid += testSlots.Spin().TotalPays()
+	}
+
+	if rtp := float64(paid) / spins; math.Abs(rtp-testSlots.Report().RTP) > 0.03 {
+		t.Errorf("spins paid back %f, but the report says %f", rtp, testSlots.Report().RTP)
+	}
+
+}
+
+func TestLinePaysBestEntry(t *testing.T) {
+
+	// Three a's match both entries, and only the best one pays
+	if got := testSlots.LinePays([]string{"a", "a", "a"}); got != 10 {
+		t.Errorf("got %d, want 10", got)
+	}
+	if got := testSlots.LinePays([]string{"b", "a", "a"}); got != 0 {
+		t.Errorf("got %d, want 0", got)
+	}
+
+}
+
+func TestShippedSlotsConfig(t *testing.T) {
+
+	config, err := LoadSlotsConfig("slots.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The machine that ships with the bot has to keep a house edge
+	if rtp := config.Report().RTP; rtp <= 0 || rtp >= 1 {
+		t.Errorf("slots.json has an RTP of %.2f%%, which should be under 100%%", rtp*100)
+	}
+
+}
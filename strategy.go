@@ -0,0 +1,357 @@
+// This file works out the mathematically correct play for a blackjack hand, used for the hint button.
+// The expected values are calculated for an infinite deck, with the dealer following the same BlackjackRules as the game.
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BlackjackPlay Represents a decision the player can make on their turn
+type BlackjackPlay int
+
+const (
+	PlayHit BlackjackPlay = iota
+	PlayStand
+	PlayDouble
+	PlaySplit
+)
+
+// Implementing the stringer interface for BlackjackPlay
+func (p BlackjackPlay) String() string {
+	return p.StringIn(DefaultLocale)
+}
+
+// StringIn Returns the name of the play in the given language
+func (p BlackjackPlay) StringIn(locale string) string {
+	switch p {
+	case PlayHit:
+		return T(locale, "strategy.hit")
+	case PlayStand:
+		return T(locale, "strategy.stand")
+	case PlayDouble:
+		return T(locale, "strategy.double")
+	case PlaySplit:
+		return T(locale, "strategy.split")
+	}
+	return T(locale, "strategy.unknown")
+}
+
+// Hand categories basic strategy is split into
+const (
+	HardHand = "hard"
+	SoftHand = "soft"
+	PairHand = "pairs"
+)
+
+// StrategyAdvice The result of working out the best play for a hand
+type StrategyAdvice struct {
+	// Play is the best play out of the ones the rules allow
+	Play BlackjackPlay
+	// Best is the best play overall, which could be one the rules don't allow
+	Best BlackjackPlay
+	// EV is the expected chips won per chip wagered for each play that was considered
+	EV map[BlackjackPlay]float64
+	// Category is the kind of hand, hard, soft or pairs
+	Category string
+	// Hand, Upcard and Rules are what the advice was worked out for
+	Hand   BlackjackHand
+	Upcard Card
+	Rules  BlackjackRules
+}
+
+// cardProbability Returns the chance of drawing a card with the given blackjack value from an infinite deck.
+// Ten, Jack, Queen and King are all worth 10, so a 10 is four times as likely as any other value.
+func cardProbability(value int) float64 {
+	if value == 10 {
+		return 4.0 / 13.0
+	}
+	return 1.0 / 13.0
+}
+
+// handTotal is a blackjack hand reduced to what matters for strategy: the total counting aces as 1, and whether it has an ace
+type handTotal struct {
+	sum    int
+	hasAce bool
+}
+
+// add Returns the hand total after drawing a card with the given value
+func (t handTotal) add(value int) handTotal {
+	return handTotal{t.sum + value, t.hasAce || value == 1}
+}
+
+// value Returns the blackjack value of the total, counting an ace as 11 if it doesn't bust the hand
+func (t handTotal) value() int {
+	if t.hasAce && t.sum+10 <= 21 {
+		return t.sum + 10
+	}
+	return t.sum
+}
+
+// soft Returns whether an ace is being counted as 11
+func (t handTotal) soft() bool {
+	return t.hasAce && t.sum+10 <= 21
+}
+
+// strategyCalculator Holds the memoized expected values while working out the advice for a single hand
+type strategyCalculator struct {
+	rules  BlackjackRules
+	upcard int
+	dealer map[dealerState]float64
+	hit    map[handTotal]float64
+}
+
+// dealerState is the key the dealer's expected results are memoized under
+type dealerState struct {
+	total       handTotal
+	holeCard    bool
+	playerValue int
+}
+
+// dealerEV Returns the expected result for a player standing on playerValue, with the dealer holding dealerTotal.
+// The dealer always draws their hidden card first, then hits according to the rules.
+func (c *strategyCalculator) dealerEV(dealerTotal handTotal, holeCard bool, playerValue int) float64 {
+
+	key := dealerState{dealerTotal, holeCard, playerValue}
+	if ev, ok := c.dealer[key]; ok {
+		return ev
+	}
+
+	var ev float64
+	dealerValue := dealerTotal.value()
+
+	if dealerValue > 21 {
+		// The dealer bust
+		ev = 1
+	} else if holeCard && !c.rules.DealerShouldHit(dealerValue, dealerTotal.soft(), playerValue) {
+		// The dealer is done, so compare the hands
+		switch {
+		case playerValue > dealerValue:
+			ev = 1
+		case playerValue < dealerValue:
+			ev = -1
+		}
+	} else {
+		// The dealer takes another card
+		for value := 1; value <= 10; value++ {
+			ev += cardProbability(value) * c.dealerEV(dealerTotal.add(value), true, playerValue)
+		}
+	}
+
+	c.dealer[key] = ev
+	return ev
+
+}
+
+// standEV Returns the expected result of standing on the hand
+func (c *strategyCalculator) standEV(total handTotal) float64 {
+	if total.value() > 21 {
+		return -1
+	}
+	return c.dealerEV(handTotal{c.upcard, c.upcard == 1}, false, total.value())
+}
+
+// hitEV Returns the expected result of hitting the hand, then playing the best of hit or stand afterwards
+func (c *strategyCalculator) hitEV(total handTotal) float64 {
+
+	if ev, ok := c.hit[total]; ok {
+		return ev
+	}
+
+	var ev float64
+	for value := 1; value <= 10; value++ {
+		next := total.add(value)
+		switch {
+		case next.value() > 21:
+			ev -= cardProbability(value)
+		case next.value() == 21:
+			// The player's turn ends automatically on 21
+			ev += cardProbability(value) * c.standEV(next)
+		default:
+			ev += cardProbability(value) * max(c.standEV(next), c.hitEV(next))
+		}
+	}
+
+	c.hit[total] = ev
+	return ev
+
+}
+
+// doubleEV Returns the expected result of doubling the wager and taking exactly one more card
+func (c *strategyCalculator) doubleEV(total handTotal) float64 {
+
+	var ev float64
+	for value := 1; value <= 10; value++ {
+		ev += cardProbability(value) * c.standEV(total.add(value))
+	}
+
+	return 2 * ev
+
+}
+
+// splitEV Returns the expected result of splitting a pair into two hands, each with their own wager.
+// Split aces only get one more card each, and split hands can't be split again.
+func (c *strategyCalculator) splitEV(cardValue int) float64 {
+
+	start := handTotal{cardValue, cardValue == 1}
+
+	var ev float64
+	for value := 1; value <= 10; value++ {
+		next := start.add(value)
+		if cardValue == 1 || next.value() == 21 {
+			ev += cardProbability(value) * c.standEV(next)
+			continue
+		}
+		best := max(c.standEV(next), c.hitEV(next))
+		if c.rules.DoubleAllowed {
+			best = max(best, c.doubleEV(next))
+		}
+		ev += cardProbability(value) * best
+	}
+
+	return 2 * ev
+
+}
+
+// BestPlay Works out the expected result of each play for the hand against the dealer's upcard, and returns the advice
+func (r BlackjackRules) BestPlay(hand BlackjackHand, upcard Card) StrategyAdvice {
+
+	c := strategyCalculator{
+		rules:  r,
+		upcard: upcard.Rank.Value(),
+		dealer: make(map[dealerState]float64),
+		hit:    make(map[handTotal]float64),
+	}
+
+	var total handTotal
+	for _, card := range hand {
+		total = total.add(card.Rank.Value())
+	}
+
+	advice := StrategyAdvice{EV: make(map[BlackjackPlay]float64), Hand: hand, Upcard: upcard, Rules: r}
+
+	// Working out which category of hand this is
+	switch {
+	case hand.IsPair():
+		advice.Category = PairHand
+	case hand.IsSoft():
+		advice.Category = SoftHand
+	default:
+		advice.Category = HardHand
+	}
+
+	advice.EV[PlayStand] = c.standEV(total)
+	advice.EV[PlayHit] = c.hitEV(total)
+	// Doubling and splitting are only options on the first two cards
+	if len(hand) == 2 {
+		advice.EV[PlayDouble] = c.doubleEV(total)
+	}
+	if hand.IsPair() {
+		advice.EV[PlaySplit] = c.splitEV(hand[0].Rank.Value())
+	}
+
+	// Finding the best play overall, and the best play the rules allow
+	advice.Play, advice.Best = PlayStand, PlayStand
+	for _, play := range []BlackjackPlay{PlayHit, PlayDouble, PlaySplit} {
+		ev, ok := advice.EV[play]
+		if !ok {
+			continue
+		}
+		if ev > advice.EV[advice.Best] {
+			advice.Best = play
+		}
+		if r.Allows(play) && ev > advice.EV[advice.Play] {
+			advice.Play = play
+		}
+	}
+
+	return advice
+
+}
+
+// Allows Returns whether the rules let the player make a play
+func (r BlackjackRules) Allows(play BlackjackPlay) bool {
+	switch play {
+	case PlayDouble:
+		return r.DoubleAllowed
+	case PlaySplit:
+		return r.SplitAllowed
+	}
+	return true
+}
+
+// Explanation Returns a message telling the player what to do and why
+func (a StrategyAdvice) Explanation() string {
+	return a.ExplanationIn(DefaultLocale)
+}
+
+// ExplanationIn Returns a message telling the player what to do and why, in the given language
+func (a StrategyAdvice) ExplanationIn(locale string) string {
+
+	var sb strings.Builder
+
+	// Describing the hand
+	description := T(locale, "strategy.hand."+a.Category, a.Hand.Value())
+	if a.Category == PairHand {
+		description = T(locale, "strategy.hand."+a.Category, a.Hand[0].Rank.PluralIn(locale))
+	}
+	sb.WriteString(T(locale, "strategy.advice", description, a.Upcard.Rank.StringIn(locale), a.Play.StringIn(locale)))
+
+	// Listing the expected result of each play, so the player can see why
+	sb.WriteString(T(locale, "strategy.ev_header"))
+	for _, play := range []BlackjackPlay{PlayHit, PlayStand, PlayDouble, PlaySplit} {
+		ev, ok := a.EV[play]
+		if !ok {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s: %+.3f", play.StringIn(locale), ev))
+		if !a.Rules.Allows(play) {
+			sb.WriteString(T(locale, "strategy.not_available"))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(a.reasonIn(locale))
+
+	// Letting the player know if a play they can't make here would have been even better
+	if a.Best != a.Play {
+		sb.WriteString(T(locale, "strategy.even_better", a.Best.StringIn(locale)))
+	}
+
+	return sb.String()
+
+}
+
+// reasonIn Returns a short explanation of why the play is the right one, in the given language
+func (a StrategyAdvice) reasonIn(locale string) string {
+
+	value := a.Hand.Value()
+	upcard := a.Upcard.Rank.Value()
+
+	switch a.Play {
+	case PlayStand:
+		if value >= 17 {
+			return T(locale, "strategy.reason.strong")
+		}
+		if upcard >= 2 && upcard <= 6 {
+			return T(locale, "strategy.reason.weak_upcard")
+		}
+		return T(locale, "strategy.reason.bust_risk")
+	case PlayHit:
+		if a.Category == SoftHand {
+			return T(locale, "strategy.reason.soft")
+		}
+		if value <= 11 {
+			return T(locale, "strategy.reason.cant_bust")
+		}
+		return T(locale, "strategy.reason.strong_upcard")
+	case PlayDouble:
+		return T(locale, "strategy.reason.double")
+	case PlaySplit:
+		return T(locale, "strategy.reason.split")
+	}
+
+	return ""
+
+}
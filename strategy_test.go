@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+// casinoRules are the rules the published basic strategy charts are for, with the dealer hitting soft 17
+var casinoRules = BlackjackRules{DealerHitsSoft17: true, DoubleAllowed: true, SplitAllowed: true}
+
+func TestBestPlay(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		hand   BlackjackHand
+		upcard Rank
+		want   BlackjackPlay
+	}{
+		{"hard 16 vs 10", BlackjackHand{{Rank: Ten, Suit: Spades}, {Rank: Six, Suit: Hearts}}, Ten, PlayHit},
+		{"hard 15 vs 7", BlackjackHand{{Rank: Ten, Suit: Spades}, {Rank: Five, Suit: Hearts}}, Seven, PlayHit},
+		{"hard 13 vs 2", BlackjackHand{{Rank: Ten, Suit: Spades}, {Rank: Three, Suit: Hearts}}, Two, PlayStand},
+		{"hard 12 vs 2", BlackjackHand{{Rank: Ten, Suit: Spades}, {Rank: Two, Suit: Hearts}}, Two, PlayHit},
+		{"hard 12 vs 4", BlackjackHand{{Rank: Ten, Suit: Spades}, {Rank: Two, Suit: Hearts}}, Four, PlayStand},
+		{"hard 17 vs ace", BlackjackHand{{Rank: Ten, Suit: Spades}, {Rank: Seven, Suit: Hearts}}, Ace, PlayStand},
+		{"hard 11 vs 6", BlackjackHand{{Rank: Six, Suit: Spades}, {Rank: Five, Suit: Hearts}}, Six, PlayDouble},
+		{"hard 10 vs 9", BlackjackHand{{Rank: Six, Suit: Spades}, {Rank: Four, Suit: Hearts}}, Nine, PlayDouble},
+		{"hard 9 vs 3", BlackjackHand{{Rank: Six, Suit: Spades}, {Rank: Three, Suit: Hearts}}, Three, PlayDouble},
+		{"soft 13 vs 5", BlackjackHand{{Rank: Ace, Suit: Spades}, {Rank: Two, Suit: Hearts}}, Five, PlayHit},
+		{"soft 18 vs 2", BlackjackHand{{Rank: Ace, Suit: Spades}, {Rank: Seven, Suit: Hearts}}, Two, PlayDouble},
+		{"soft 18 vs 7", BlackjackHand{{Rank: Ace, Suit: Spades}, {Rank: Seven, Suit: Hearts}}, Seven, PlayStand},
+		{"soft 18 vs 9", BlackjackHand{{Rank: Ace, Suit: Spades}, {Rank: Seven, Suit: Hearts}}, Nine, PlayHit},
+		{"soft 19 vs 6", BlackjackHand{{Rank: Ace, Suit: Spades}, {Rank: Eight, Suit: Hearts}}, Six, PlayDouble},
+		{"aces vs 6", BlackjackHand{{Rank: Ace, Suit: Spades}, {Rank: Ace, Suit: Hearts}}, Six, PlaySplit},
+		{"eights vs 9", BlackjackHand{{Rank: Eight, Suit: Spades}, {Rank: Eight, Suit: Hearts}}, Nine, PlaySplit},
+		{"nines vs 7", BlackjackHand{{Rank: Nine, Suit: Spades}, {Rank: Nine, Suit: Hearts}}, Seven, PlayStand},
+		{"tens vs 6", BlackjackHand{{Rank: Ten, Suit: Spades}, {Rank: King, Suit: Hearts}}, Six, PlayStand},
+		{"fives vs 6", BlackjackHand{{Rank: Five, Suit: Spades}, {Rank: Five, Suit: Hearts}}, Six, PlayDouble},
+	}
+
+	for _, test := range tests {
+		advice := casinoRules.BestPlay(test.hand, Card{Rank: test.upcard, Suit: Clubs})
+		if advice.Play != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, advice.Play, test.want)
+		}
+	}
+
+}
+
+func TestBestPlayFollowsRules(t *testing.T) {
+
+	// Doubling is the best play on 11 against a 6, but it isn't offered when the rules don't allow it
+	rules := casinoRules
+	rules.DoubleAllowed = false
+
+	advice := rules.BestPlay(BlackjackHand{{Rank: Six, Suit: Spades}, {Rank: Five, Suit: Hearts}}, Card{Rank: Six, Suit: Clubs})
+	if advice.Best != PlayDouble || advice.Play != PlayHit {
+		t.Errorf("got play %v and best %v, want %v and %v", advice.Play, advice.Best, PlayHit, PlayDouble)
+	}
+	if advice.Category != HardHand {
+		t.Errorf("got category %q, want %q", advice.Category, HardHand)
+	}
+
+}
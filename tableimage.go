@@ -0,0 +1,260 @@
+// This file renders blackjack tables as PNG images. The card art is bundled here as small bitmaps, which are scaled up
+// and drawn onto the felt, so the images don't depend on any fonts or files being installed. Each card face is drawn
+// once and cached, and the same table always renders to the same image.
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Sizes of the cards and table, in pixels
+const (
+	CardImageWidth  = 60
+	CardImageHeight = 84
+	cardImageGap    = 8
+	tableMargin     = 16
+	tableMinWidth   = 320
+	// glyphScale is how many pixels wide each pixel of the bitmap font is drawn
+	glyphScale = 2
+)
+
+var (
+	feltColor      = color.RGBA{R: 0x0b, G: 0x6b, B: 0x3a, A: 0xff}
+	cardColor      = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	cardEdgeColor  = color.RGBA{R: 0x99, G: 0x99, B: 0x99, A: 0xff}
+	cardBackColor  = color.RGBA{R: 0x1f, G: 0x3f, B: 0x9f, A: 0xff}
+	cardTrimColor  = color.RGBA{R: 0xd0, G: 0xd8, B: 0xf0, A: 0xff}
+	redSuitColor   = color.RGBA{R: 0xcc, G: 0x11, B: 0x22, A: 0xff}
+	blackSuitColor = color.RGBA{R: 0x11, G: 0x11, B: 0x11, A: 0xff}
+	labelColor     = color.RGBA{R: 0xf4, G: 0xe8, B: 0xc1, A: 0xff}
+)
+
+// glyphs The bitmap font, 5 pixels wide and 7 tall. Anything drawn in the image must be one of these characters.
+var glyphs = map[rune][]string{
+	'A': {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B': {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C': {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D': {"####.", "#...#", "#...#", "#...#", "#...#", "#...#", "####."},
+	'E': {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F': {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G': {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	'H': {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I': {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'J': {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K': {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L': {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M': {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'N': {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'O': {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P': {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q': {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R': {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S': {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'T': {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U': {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V': {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W': {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	'X': {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y': {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#.."},
+	'Z': {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+	'0': {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	'?': {".###.", "#...#", "....#", "...#.", "..#..", ".....", "..#.."},
+	'-': {".....", ".....", ".....", "#####", ".....", ".....", "....."},
+	' ': {".....", ".....", ".....", ".....", ".....", ".....", "....."},
+}
+
+// pips The suit symbols, 9 pixels square, in the same order as the suits
+var pips = [][]string{
+	// Clubs
+	{"...###...", "..#####..", "..#####..", "##.###.##", "#########", "##.###.##", "....#....", "...###...", "..#####.."},
+	// Diamonds
+	{"....#....", "...###...", "..#####..", ".#######.", "#########", ".#######.", "..#####..", "...###...", "....#...."},
+	// Hearts
+	{".##...##.", "####.####", "#########", "#########", ".#######.", "..#####..", "...###...", "....#....", "........."},
+	// Spades
+	{"....#....", "...###...", "..#####..", ".#######.", "#########", "#########", ".##.#.##.", "....#....", "...###..."},
+}
+
+var (
+	// cardImages card faces that have been drawn, by card
+	cardImages   = make(map[Card]*image.RGBA)
+	cardImagesMu sync.Mutex
+
+	cardBackImage     *image.RGBA
+	cardBackImageOnce sync.Once
+)
+
+// drawBitmap Draws a bitmap onto the image with its top left corner at the point, with each pixel scaled up
+func drawBitmap(img draw.Image, bitmap []string, at image.Point, scale int, c color.Color) {
+	src := image.NewUniform(c)
+	for y, row := range bitmap {
+		for x, pixel := range row {
+			if pixel != '#' {
+				continue
+			}
+			r := image.Rect(at.X+x*scale, at.Y+y*scale, at.X+(x+1)*scale, at.Y+(y+1)*scale)
+			draw.Draw(img, r, src, image.Point{}, draw.Src)
+		}
+	}
+}
+
+// drawText Draws the text in the bitmap font, returning the width it took up. Characters without a glyph are skipped.
+func drawText(img draw.Image, text string, at image.Point, scale int, c color.Color) int {
+	x := at.X
+	for _, r := range strings.ToUpper(text) {
+		glyph, ok := glyphs[r]
+		if !ok {
+			continue
+		}
+		drawBitmap(img, glyph, image.Pt(x, at.Y), scale, c)
+		x += 6 * scale
+	}
+	return x - at.X
+}
+
+// blankCard Returns a card sized image filled with the color, with a border and rounded corners on the felt
+func blankCard(fill color.Color) *image.RGBA {
+
+	img := image.NewRGBA(image.Rect(0, 0, CardImageWidth, CardImageHeight))
+	draw.Draw(img, img.Bounds(), image.NewUniform(cardEdgeColor), image.Point{}, draw.Src)
+	draw.Draw(img, img.Bounds().Inset(1), image.NewUniform(fill), image.Point{}, draw.Src)
+
+	// Cutting the corners so they look rounded
+	for _, corner := range []image.Point{{0, 0}, {CardImageWidth - 1, 0}, {0, CardImageHeight - 1}, {CardImageWidth - 1, CardImageHeight - 1}} {
+		img.Set(corner.X, corner.Y, feltColor)
+	}
+
+	return img
+
+}
+
+// CardImage Returns the image of the card's face, drawing it the first time it's needed
+func CardImage(card Card) *image.RGBA {
+
+	cardImagesMu.Lock()
+	defer cardImagesMu.Unlock()
+
+	if img, ok := cardImages[card]; ok {
+		return img
+	}
+
+	img := blankCard(cardColor)
+
+	ink := blackSuitColor
+	if card.Suit == Hearts || card.Suit == Diamonds {
+		ink = redSuitColor
+	}
+
+	// The rank and a small pip in the top corner, and a large pip in the middle
+	drawText(img, card.Rank.Short(), image.Pt(5, 5), glyphScale, ink)
+	drawBitmap(img, pips[card.Suit], image.Pt(5, 23), 1, ink)
+	drawBitmap(img, pips[card.Suit], image.Pt((CardImageWidth-9*3)/2, (CardImageHeight-9*3)/2+8), 3, ink)
+
+	cardImages[card] = img
+
+	return img
+
+}
+
+// CardBackImage Returns the image of the back of a card, for the dealer's face down card
+func CardBackImage() *image.RGBA {
+
+	cardBackImageOnce.Do(func() {
+		img := blankCard(cardBackColor)
+		trim := image.NewUniform(cardTrimColor)
+		// A checked pattern inside a border
+		for y := 6; y < CardImageHeight-6; y += 6 {
+			for x := 6; x < CardImageWidth-6; x += 6 {
+				if (x/6+y/6)%2 == 0 {
+					draw.Draw(img, image.Rect(x, y, x+3, y+3), trim, image.Point{}, draw.Src)
+				}
+			}
+		}
+		cardBackImage = img
+	})
+
+	return cardBackImage
+
+}
+
+// RenderBlackjackTable Draws the dealer's and player's hands with their totals and the wager. While hideHole is true
+// the dealer's second card is face down and their total isn't shown. A wager of 0 is shown as a practice hand.
+func RenderBlackjackTable(dealer []Card, player []Card, hideHole bool, wager int) *image.RGBA {
+
+	lineHeight := 7*glyphScale + cardImageGap
+	rowHeight := lineHeight + CardImageHeight + tableMargin
+
+	cards := max(len(dealer), len(player))
+	width := max(tableMinWidth, 2*tableMargin+cards*CardImageWidth+(cards-1)*cardImageGap)
+	height := 2*tableMargin + 2*rowHeight + 7*glyphScale
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(feltColor), image.Point{}, draw.Src)
+
+	dealerTotal := strconv.Itoa(BlackjackHand(dealer).Value())
+	if hideHole {
+		dealerTotal = "?"
+	}
+
+	rows := []struct {
+		label string
+		cards []Card
+		// hide is true if the second card is face down
+		hide bool
+	}{
+		{"DEALER " + dealerTotal, dealer, hideHole},
+		{"PLAYER " + strconv.Itoa(BlackjackHand(player).Value()), player, false},
+	}
+
+	y := tableMargin
+	for _, row := range rows {
+		drawText(img, row.label, image.Pt(tableMargin, y), glyphScale, labelColor)
+
+		x := tableMargin
+		for i, card := range row.cards {
+			face := CardImage(card)
+			if row.hide && i == 1 {
+				face = CardBackImage()
+			}
+			r := image.Rect(x, y+lineHeight, x+CardImageWidth, y+lineHeight+CardImageHeight)
+			draw.Draw(img, r, face, image.Point{}, draw.Src)
+			x += CardImageWidth + cardImageGap
+		}
+
+		y += rowHeight
+	}
+
+	footer := "WAGER " + strconv.Itoa(wager)
+	if wager == 0 {
+		footer = "PRACTICE HAND"
+	}
+	drawText(img, footer, image.Pt(tableMargin, y), glyphScale, labelColor)
+
+	return img
+
+}
+
+// EncodePNG Returns the image encoded as a PNG
+func EncodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestRenderBlackjackTable(t *testing.T) {
+
+	dealer := []Card{{Rank: King, Suit: Spades}, {Rank: Seven, Suit: Hearts}}
+	player := []Card{{Rank: Ace, Suit: Diamonds}, {Rank: Five, Suit: Clubs}, {Rank: Ten, Suit: Hearts}}
+
+	got, err := EncodePNG(RenderBlackjackTable(dealer, player, true, 25))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "blackjack_table.png")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("the rendered table doesn't match %s. If the change is intended, run go test -run TestRenderBlackjackTable -update", golden)
+	}
+
+}
@@ -0,0 +1,100 @@
+// This file handles the blackjack trainer, where no chips are at stake and each decision is checked against basic strategy.
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/rodaine/table"
+)
+
+// TrainerStat The player's blackjack trainer accuracy for one category of hand
+type TrainerStat struct {
+	Category string
+	Correct  int
+	Total    int
+}
+
+// Accuracy Returns the percentage of decisions that were correct
+func (t TrainerStat) Accuracy() float64 {
+	if t.Total == 0 {
+		return 0
+	}
+	return float64(t.Correct) / float64(t.Total) * 100
+}
+
+// CheckTrainerDecision compares the play the player chose to basic strategy, and saves the result to the database.
+// Must be called before the play is made, so the advice is for the hand the player made their decision on.
+// Returns a message telling the player if they were right, or what they should have done.
+func CheckTrainerDecision(game *Blackjack, play BlackjackPlay) string {
+
+	advice := game.Rules.BestPlay(game.PlayerHand, game.DealerHand[0])
+	correct := advice.Play == play
+
+	game.TrainerDecisions++
+	if correct {
+		game.TrainerCorrect++
+	}
+	dba.RecordTrainerDecision(game.Player, advice.Category, correct)
+
+	if correct {
+		return T(game.Locale, "trainer.decision_correct", play.StringIn(game.Locale))
+	}
+
+	return T(game.Locale, "trainer.decision_mistake",
+		advice.Play.StringIn(game.Locale),
+		strings.ToLower(play.StringIn(game.Locale)),
+		advice.reasonIn(game.Locale))
+
+}
+
+// TrainerGameOver Shows the results of a trainer game in the game's message and removes it from the map.
+// No chips or stats change, only the player's trainer accuracy.
+func TrainerGameOver(game Blackjack, message string) {
+
+	result, color := game.Results()
+	message += "\n\n**" + result + "**" + T(game.Locale, "blackjack.trainer_result")
+
+	if game.TrainerDecisions > 0 {
+		message += "\n\n" + TN(game.Locale, "blackjack.trainer_decisions", game.TrainerDecisions, game.TrainerCorrect, game.TrainerDecisions)
+	}
+
+	// Removing the game from the map since it is done now
+	delete(BlackjackGamesMap, game.Player.Username)
+	game.ShowTable(message, color, []discordgo.MessageComponent{})
+
+}
+
+// GetTrainerStats Returns a table in the given language of the player's blackjack trainer accuracy for each category
+// of hand
+func GetTrainerStats(locale string, player Player) string {
+
+	stats := dba.GetTrainerStats(player)
+
+	if len(stats) == 0 {
+		return T(locale, "trainer.no_stats", player.Username)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(T(locale, "trainer.accuracy", strings.ToUpper(player.Username)))
+	sb.WriteString("================================================\n")
+
+	tbl := table.New(T(locale, "trainer.hands"), T(locale, "trainer.correct"), T(locale, "trainer.total"), T(locale, "trainer.accuracy_column"))
+
+	overall := TrainerStat{Category: "overall"}
+	for _, stat := range stats {
+		tbl.AddRow(T(locale, "trainer.category."+stat.Category), stat.Correct, stat.Total, fmt.Sprintf("%.1f%%", stat.Accuracy()))
+		overall.Correct += stat.Correct
+		overall.Total += stat.Total
+	}
+	tbl.AddRow(T(locale, "trainer.category."+overall.Category), overall.Correct, overall.Total, fmt.Sprintf("%.1f%%", overall.Accuracy()))
+
+	tbl.WithWriter(&sb)
+	tbl.Print()
+
+	// Printing this inside ``` ``` wrapping to make it block text in discord, so formatting is not messed up
+	return "```" + sb.String() + "```"
+
+}
@@ -0,0 +1,226 @@
+// This file handles giving chips to other members. The sender confirms each transfer with a button, the house takes
+// the configured tax out of it, and each member can only send so many chips a day. Every transfer is recorded in a
+// ledger, which the daily limit is checked against. New Discord accounts can't send chips, so alts can't be made just to
+// pass their starting chips on.
+package main
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// TransferConfirmTimeout is how long the sender has to confirm a transfer before it's called off
+const TransferConfirmTimeout = time.Minute
+
+// TransferLimitWindow is the window the daily transfer limit is counted over
+const TransferLimitWindow = 24 * time.Hour
+
+// Reasons a transfer can't go through
+var (
+	ErrTransferNotEnoughChips = errors.New("not enough chips for the transfer")
+	ErrTransferLimit          = errors.New("transfer would go over the daily limit")
+)
+
+// Transfer A transfer of chips from one member to another, waiting to be confirmed
+type Transfer struct {
+	Sender      string
+	Recipient   string
+	RecipientID string
+	Amount      int
+	// Tax is the chips the house takes out of the amount, so the recipient gets Amount - Tax
+	Tax int
+	// Interaction is the command that asked for the transfer, used to edit the message when it expires
+	Interaction *discordgo.Interaction
+}
+
+var (
+	// TransfersMap transfers waiting to be confirmed, by the ID of the command that asked for them
+	TransfersMap = make(map[string]*Transfer)
+	transfersMu  sync.Mutex
+)
+
+// TransferTax Returns the chips the house takes from a transfer, rounded down
+func TransferTax(amount int) int {
+	return int(float64(amount) * Config.TransferTaxPercent / 100)
+}
+
+// AccountAge Returns how long ago the Discord account with the ID was created, from the timestamp in the ID
+func AccountAge(userID string) time.Duration {
+
+	created, err := discordgo.SnowflakeTimestamp(userID)
+	if err != nil {
+		log.Println(err)
+		return 0
+	}
+
+	return time.Since(created)
+
+}
+
+// takeTransfer Removes the transfer the button was pressed on from the map and returns it, so it can only be
+// confirmed once. Returns nil if the transfer has already been confirmed, cancelled or called off.
+func takeTransfer(i *discordgo.InteractionCreate) *Transfer {
+
+	if i.Message.Interaction == nil {
+		return nil
+	}
+
+	transfersMu.Lock()
+	defer transfersMu.Unlock()
+
+	transfer, ok := TransfersMap[i.Message.Interaction.ID]
+	if !ok {
+		return nil
+	}
+	delete(TransfersMap, i.Message.Interaction.ID)
+
+	return transfer
+
+}
+
+// GiveCommand handles the /give command, checking the transfer is allowed and asking the sender to confirm it
+func GiveCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	// Getting options and storing in map
+	options := i.ApplicationCommandData().Options
+	optionMap := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		optionMap[opt.Name] = opt
+	}
+
+	locale := LocaleFor(i)
+	recipient := optionMap["member"].UserValue(s)
+	amount := int(optionMap["amount"].IntValue())
+
+	if recipient.ID == i.Member.User.ID || recipient.Bot {
+		RespondEphemeral(i, T(locale, "give.self"))
+		return
+	}
+
+	minAge := time.Duration(Config.TransferMinAccountDays) * 24 * time.Hour
+	if AccountAge(i.Member.User.ID) < minAge {
+		RespondEphemeral(i, TN(locale, "give.account_too_new", Config.TransferMinAccountDays, Config.TransferMinAccountDays))
+		return
+	}
+
+	sender := dba.FindPlayer(i.Member.User.Username)
+	if sender.Chips < amount {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", sender.Chips))
+		return
+	}
+
+	if Config.TransferDailyLimit > 0 {
+		sent := dba.GetChipsSent(sender.Username, time.Now().Add(-TransferLimitWindow))
+		if sent+amount > Config.TransferDailyLimit {
+			RespondEphemeral(i, T(locale, "give.limit", Config.TransferDailyLimit, max(Config.TransferDailyLimit-sent, 0)))
+			return
+		}
+	}
+
+	transfer := &Transfer{
+		Sender:      sender.Username,
+		Recipient:   recipient.Username,
+		RecipientID: recipient.ID,
+		Amount:      amount,
+		Tax:         TransferTax(amount),
+		Interaction: i.Interaction,
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: T(locale, "give.confirm", amount, recipient.Username, transfer.Tax, amount-transfer.Tax),
+			Flags:   discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.Button{Label: T(locale, "give.confirm_button"), Style: discordgo.SuccessButton, CustomID: "give-confirm"},
+						discordgo.Button{Label: T(locale, "give.cancel_button"), Style: discordgo.SecondaryButton, CustomID: "give-cancel"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	transfersMu.Lock()
+	TransfersMap[i.ID] = transfer
+	transfersMu.Unlock()
+
+	// Calling the transfer off if it isn't confirmed in time
+	time.AfterFunc(TransferConfirmTimeout, func() {
+		transfersMu.Lock()
+		_, waiting := TransfersMap[transfer.Interaction.ID]
+		delete(TransfersMap, transfer.Interaction.ID)
+		transfersMu.Unlock()
+
+		if !waiting {
+			return
+		}
+
+		content := T(locale, "give.expired")
+		components := []discordgo.MessageComponent{}
+		_, err := s.InteractionResponseEdit(transfer.Interaction, &discordgo.WebhookEdit{Content: &content, Components: &components})
+		if err != nil {
+			log.Println(err)
+		}
+	})
+
+}
+
+// GiveButton handles the confirm and cancel buttons on a transfer, making the transfer if it was confirmed
+func GiveButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	locale := LocaleFor(i)
+
+	transfer := takeTransfer(i)
+	if transfer == nil {
+		RemoveButtonsFromInteractionMessage(i)
+		return
+	}
+
+	var content string
+
+	if i.MessageComponentData().CustomID == "give-cancel" {
+		content = T(locale, "give.cancelled")
+	} else {
+		sender := dba.FindPlayer(transfer.Sender)
+		// Making sure the recipient has a player entry to give the chips to
+		dba.FindPlayer(transfer.Recipient)
+
+		err := dba.TransferChips(&sender, transfer.Recipient, transfer.Amount, transfer.Tax, Config.TransferDailyLimit, time.Now())
+		switch {
+		case errors.Is(err, ErrTransferNotEnoughChips):
+			content = T(locale, "game.not_enough_chips", sender.Chips)
+		case errors.Is(err, ErrTransferLimit):
+			content = T(locale, "give.limit_reached", Config.TransferDailyLimit)
+		default:
+			content = T(locale, "give.sent", transfer.Amount-transfer.Tax, transfer.Recipient) + T(locale, "net.total", sender.Chips)
+
+			// Letting the recipient know, since the confirmation is only shown to the sender
+			_, err = s.ChannelMessageSend(i.ChannelID, T(locale, "give.announce", transfer.RecipientID, sender.Username, transfer.Amount-transfer.Tax))
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
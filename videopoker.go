@@ -0,0 +1,272 @@
+// This file implements Jacks or Better video poker. The player is dealt five cards, picks which to hold, and the rest
+// are replaced in a single draw. The final hand is paid from a standard 9/6 paytable.
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// VideoPokerPaytable The chips paid for each chip wagered on a winning hand, including the wager itself.
+// This is the full pay 9/6 Jacks or Better table, named after what a full house and flush pay.
+var VideoPokerPaytable = map[PokerCategory]int{
+	RoyalFlush:    800,
+	StraightFlush: 50,
+	FourOfAKind:   25,
+	FullHouse:     9,
+	Flush:         6,
+	Straight:      4,
+	ThreeOfAKind:  3,
+	TwoPair:       2,
+	OnePair:       1,
+}
+
+// VideoPokerPays Returns the chips paid for each chip wagered on the hand. A pair only pays if it is jacks or better.
+func VideoPokerPays(hand PokerHand) int {
+	if hand.Category == OnePair && hand.Tiebreak[0] < Jack.High() {
+		return 0
+	}
+	return VideoPokerPaytable[hand.Category]
+}
+
+// VideoPoker A hand of video poker being played
+type VideoPoker struct {
+	mu sync.Mutex
+
+	Player   Player
+	Wager    int
+	EscrowID int64
+	Deck     Deck
+	Hand     []Card
+	Held     [5]bool
+	// InteractionID is the ID of the command that dealt the hand, to tell which message its buttons are on
+	InteractionID string
+	// Locale is the language the game is shown in
+	Locale string
+	Over   bool
+}
+
+var (
+	// VideoPokerGamesMap hands of video poker being played, by the player's username
+	VideoPokerGamesMap = make(map[string]*VideoPoker)
+	videoPokerGamesMu  sync.Mutex
+)
+
+// NewVideoPoker Shuffles a deck and deals the player five cards
+func NewVideoPoker(player Player, wager int) *VideoPoker {
+
+	game := &VideoPoker{Player: player, Wager: wager, Deck: NewShoe(1)}
+	for i := 0; i < 5; i++ {
+		game.Hand = append(game.Hand, game.Deck.DealCard())
+	}
+
+	return game
+
+}
+
+// Draw Replaces every card that isn't held with a new card from the deck, and returns the final hand
+func (g *VideoPoker) Draw() PokerHand {
+
+	for i := range g.Hand {
+		if !g.Held[i] {
+			g.Hand[i] = g.Deck.DealCard()
+		}
+	}
+
+	return EvaluatePokerHand(g.Hand)
+
+}
+
+// Content Returns the message showing the player's cards, and which are held
+func (g *VideoPoker) Content() string {
+
+	var sb strings.Builder
+
+	sb.WriteString(T(g.Locale, "videopoker.playing", g.Player.Username, g.Wager))
+	for i, card := range g.Hand {
+		sb.WriteString(fmt.Sprintf("%d. %s", i+1, card))
+		if g.Held[i] {
+			sb.WriteString(T(g.Locale, "videopoker.held"))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+
+}
+
+// Components Returns a hold button for each card, and the draw button
+func (g *VideoPoker) Components() []discordgo.MessageComponent {
+
+	var holds []discordgo.MessageComponent
+	for i := range g.Hand {
+		style := discordgo.SecondaryButton
+		if g.Held[i] {
+			style = discordgo.PrimaryButton
+		}
+		holds = append(holds, discordgo.Button{
+			Label:    T(g.Locale, "videopoker.hold_button", i+1),
+			Style:    style,
+			CustomID: "videopoker-hold-" + strconv.Itoa(i),
+		})
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: holds},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: T(g.Locale, "videopoker.draw_button"), Style: discordgo.SuccessButton, CustomID: "videopoker-draw"},
+			},
+		},
+	}
+
+}
+
+// VideoPokerCommand handles the /videopoker command, dealing the player their first five cards
+func VideoPokerCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	wager := int(i.ApplicationCommandData().Options[0].IntValue())
+	locale := LocaleFor(i)
+	player := dba.FindPlayer(i.Member.User.Username)
+
+	if player.Chips < wager {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", player.Chips))
+		return
+	}
+
+	videoPokerGamesMu.Lock()
+	if _, ok := VideoPokerGamesMap[player.Username]; ok {
+		videoPokerGamesMu.Unlock()
+		RespondEphemeral(i, T(locale, "videopoker.in_game"))
+		return
+	}
+	game := NewVideoPoker(player, wager)
+	game.InteractionID = i.ID
+	game.Locale = locale
+
+	// Holding the wager in escrow until the draw, so it can't be spent while the player decides what to hold
+	escrowID, err := dba.EscrowChips(&game.Player, wager)
+	if err != nil {
+		videoPokerGamesMu.Unlock()
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", dba.GetChipTotal(player.Username)))
+		return
+	}
+	game.EscrowID = escrowID
+	VideoPokerGamesMap[player.Username] = game
+	videoPokerGamesMu.Unlock()
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    game.Content() + "\n" + T(game.Locale, "videopoker.hold_prompt"),
+			Components: game.Components(),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// findVideoPokerGame Returns the hand being played by the player who pressed a button, as long as the button is on
+// that hand's message. Otherwise the interaction is acknowledged and nil is returned.
+func findVideoPokerGame(i *discordgo.InteractionCreate) *VideoPoker {
+
+	videoPokerGamesMu.Lock()
+	game, ok := VideoPokerGamesMap[i.Member.User.Username]
+	videoPokerGamesMu.Unlock()
+
+	// Buttons pressed by other players, or on old hands, are ignored
+	if !ok || i.Message.Interaction == nil || i.Message.Interaction.ID != game.InteractionID {
+		RespondEphemeral(i, T(LocaleFor(i), "videopoker.not_yours"))
+		return nil
+	}
+
+	return game
+
+}
+
+// VideoPokerHoldButton handles the hold buttons, toggling whether the card is kept on the draw
+func VideoPokerHoldButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	game := findVideoPokerGame(i)
+	if game == nil {
+		return
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if game.Over {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	card, _ := strconv.Atoi(strings.TrimPrefix(i.MessageComponentData().CustomID, "videopoker-hold-"))
+	game.Held[card] = !game.Held[card]
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    game.Content() + "\n" + T(game.Locale, "videopoker.hold_prompt"),
+			Components: game.Components(),
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// VideoPokerDrawButton handles the draw button, replacing the cards that aren't held and paying the final hand
+func VideoPokerDrawButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	game := findVideoPokerGame(i)
+	if game == nil {
+		return
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	// Ending the hand first, so pressing draw twice can't pay it twice
+	if game.Over {
+		AcknowledgeInteraction(i)
+		return
+	}
+	game.Over = true
+
+	videoPokerGamesMu.Lock()
+	delete(VideoPokerGamesMap, game.Player.Username)
+	videoPokerGamesMu.Unlock()
+
+	hand := game.Draw()
+	net := VideoPokerPays(hand)*game.Wager - game.Wager
+
+	message := T(game.Locale, "videopoker.no_win")
+	if pays := VideoPokerPays(hand); pays > 0 {
+		message = T(game.Locale, "videopoker.pays", hand.Category.StringIn(game.Locale), pays*game.Wager)
+	}
+
+	// Settling against the escrowed wager, so anything the player won or spent while deciding what to hold is kept
+	settled, _ := dba.SettleEscrow(game.EscrowID, &game.Player, game.Locale, net)
+	message += settled
+
+	game.Held = [5]bool{}
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    game.Content() + "\n" + message,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestVideoPokerPays(t *testing.T) {
+
+	tests := []struct {
+		name string
+		hand []Card
+		want int
+	}{
+		{"royal flush", []Card{{Rank: Ace, Suit: Hearts}, {Rank: King, Suit: Hearts}, {Rank: Queen, Suit: Hearts}, {Rank: Jack, Suit: Hearts}, {Rank: Ten, Suit: Hearts}}, 800},
+		{"straight flush", []Card{{Rank: Nine, Suit: Clubs}, {Rank: Eight, Suit: Clubs}, {Rank: Seven, Suit: Clubs}, {Rank: Six, Suit: Clubs}, {Rank: Five, Suit: Clubs}}, 50},
+		{"four of a kind", []Card{{Rank: Two, Suit: Clubs}, {Rank: Two, Suit: Diamonds}, {Rank: Two, Suit: Hearts}, {Rank: Two, Suit: Spades}, {Rank: King, Suit: Clubs}}, 25},
+		{"full house", []Card{{Rank: Three, Suit: Clubs}, {Rank: Three, Suit: Diamonds}, {Rank: Three, Suit: Hearts}, {Rank: Nine, Suit: Spades}, {Rank: Nine, Suit: Clubs}}, 9},
+		{"flush", []Card{{Rank: Two, Suit: Spades}, {Rank: Six, Suit: Spades}, {Rank: Nine, Suit: Spades}, {Rank: Jack, Suit: Spades}, {Rank: King, Suit: Spades}}, 6},
+		{"ace low straight", []Card{{Rank: Ace, Suit: Clubs}, {Rank: Two, Suit: Diamonds}, {Rank: Three, Suit: Hearts}, {Rank: Four, Suit: Spades}, {Rank: Five, Suit: Clubs}}, 4},
+		{"three of a kind", []Card{{Rank: Seven, Suit: Clubs}, {Rank: Seven, Suit: Diamonds}, {Rank: Seven, Suit: Hearts}, {Rank: Two, Suit: Spades}, {Rank: King, Suit: Clubs}}, 3},
+		{"two pair", []Card{{Rank: Four, Suit: Clubs}, {Rank: Four, Suit: Diamonds}, {Rank: Eight, Suit: Hearts}, {Rank: Eight, Suit: Spades}, {Rank: King, Suit: Clubs}}, 2},
+		{"pair of jacks", []Card{{Rank: Jack, Suit: Clubs}, {Rank: Jack, Suit: Diamonds}, {Rank: Two, Suit: Hearts}, {Rank: Five, Suit: Spades}, {Rank: Nine, Suit: Clubs}}, 1},
+		{"pair of aces", []Card{{Rank: Ace, Suit: Clubs}, {Rank: Ace, Suit: Diamonds}, {Rank: Two, Suit: Hearts}, {Rank: Five, Suit: Spades}, {Rank: Nine, Suit: Clubs}}, 1},
+		{"pair of tens", []Card{{Rank: Ten, Suit: Clubs}, {Rank: Ten, Suit: Diamonds}, {Rank: Two, Suit: Hearts}, {Rank: Five, Suit: Spades}, {Rank: Nine, Suit: Clubs}}, 0},
+		{"high card", []Card{{Rank: Ace, Suit: Clubs}, {Rank: King, Suit: Diamonds}, {Rank: Two, Suit: Hearts}, {Rank: Five, Suit: Spades}, {Rank: Nine, Suit: Clubs}}, 0},
+	}
+
+	for _, test := range tests {
+		if got := VideoPokerPays(EvaluatePokerHand(test.hand)); got != test.want {
+			t.Errorf("%s: got %d, want %d", test.name, got, test.want)
+		}
+	}
+
+}
@@ -0,0 +1,259 @@
+// This file implements casino war. The player and dealer are each dealt a card and the higher card wins, with aces
+// high. If the cards tie, the player can surrender half their wager, or go to war by raising the same again. Three
+// cards are burned, another card is dealt to each, and the player wins the raise if their card isn't lower, while the
+// original wager pushes. If the dealer's card is higher the player loses both.
+package main
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// WarDecks is the number of decks in the shoe for a game of war
+const WarDecks = 6
+
+// WarBurnCards is the number of cards burned before the cards are dealt when going to war
+const WarBurnCards = 3
+
+// War A game of casino war that tied, waiting for the player to go to war or surrender
+type War struct {
+	mu sync.Mutex
+
+	Player Player
+	Wager  int
+	// EscrowIDs are the escrows holding the wager, and the raise once the player goes to war
+	EscrowIDs []int64
+	Deck      Deck
+	// PlayerCards and DealerCards are the cards dealt to each side, the last of which is the one that counts
+	PlayerCards []Card
+	DealerCards []Card
+	// InteractionID is the ID of the command that started the game, to tell which message its buttons are on
+	InteractionID string
+	// Locale is the language the game is shown in
+	Locale string
+	Over   bool
+}
+
+var (
+	// WarGamesMap games of war waiting for the player to decide on a tie, by the player's username
+	WarGamesMap = make(map[string]*War)
+	warGamesMu  sync.Mutex
+)
+
+// NewWar Shuffles a shoe and deals a card each to the player and dealer
+func NewWar(player Player, wager int) *War {
+
+	game := &War{Player: player, Wager: wager, Deck: NewShoe(WarDecks), Locale: DefaultLocale}
+	game.Deal()
+
+	return game
+
+}
+
+// Deal Deals a card each to the player and dealer
+func (g *War) Deal() {
+	g.PlayerCards = append(g.PlayerCards, g.Deck.DealCard())
+	g.DealerCards = append(g.DealerCards, g.Deck.DealCard())
+}
+
+// Compare Returns 1 if the player's last card is higher, -1 if the dealer's is, and 0 for a tie
+func (g *War) Compare() int {
+
+	player := g.PlayerCards[len(g.PlayerCards)-1].Rank.High()
+	dealer := g.DealerCards[len(g.DealerCards)-1].Rank.High()
+
+	switch {
+	case player > dealer:
+		return 1
+	case player < dealer:
+		return -1
+	default:
+		return 0
+	}
+
+}
+
+// GoToWar Burns three cards and deals another card each. Returns the player's net, which wins the raise if their card
+// is at least as high as the dealer's, and loses the wager and the raise otherwise.
+func (g *War) GoToWar() int {
+
+	for i := 0; i < WarBurnCards; i++ {
+		g.Deck.DealCard()
+	}
+	g.Deal()
+
+	if g.Compare() < 0 {
+		return -2 * g.Wager
+	}
+	return g.Wager
+
+}
+
+// Content Returns the message showing the cards dealt to each side
+func (g *War) Content() string {
+
+	var sb strings.Builder
+
+	sb.WriteString(T(g.Locale, "war.playing", g.Player.Username, g.Wager))
+	for round := range g.PlayerCards {
+		if round > 0 {
+			sb.WriteString(T(g.Locale, "war.burned", WarBurnCards))
+		}
+		sb.WriteString(T(g.Locale, "war.player_card", g.Player.Username, g.PlayerCards[round]))
+		sb.WriteString(T(g.Locale, "war.dealer_card", g.DealerCards[round]))
+	}
+
+	return sb.String()
+
+}
+
+// Components Returns the go to war and surrender buttons
+func (g *War) Components() []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: T(g.Locale, "war.go_button", g.Wager), Style: discordgo.DangerButton, CustomID: "war-go"},
+				discordgo.Button{Label: T(g.Locale, "war.surrender_button", g.Wager-g.Wager/2), Style: discordgo.SecondaryButton, CustomID: "war-surrender"},
+			},
+		},
+	}
+}
+
+// settle Settles the player's net against the escrowed wager and raise, and returns a message with the result
+func (g *War) settle(net int) string {
+
+	message, _ := dba.SettleEscrows(g.EscrowIDs, &g.Player, g.Locale, net)
+
+	return message
+
+}
+
+// WarCommand handles the /war command, dealing the cards and settling the game unless they tie
+func WarCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	wager := int(i.ApplicationCommandData().Options[0].IntValue())
+	locale := LocaleFor(i)
+	player := dba.FindPlayer(i.Member.User.Username)
+
+	if player.Chips < wager {
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", player.Chips))
+		return
+	}
+
+	warGamesMu.Lock()
+	if _, ok := WarGamesMap[player.Username]; ok {
+		warGamesMu.Unlock()
+		RespondEphemeral(i, T(locale, "war.in_game"))
+		return
+	}
+	game := NewWar(player, wager)
+	game.InteractionID = i.ID
+	game.Locale = locale
+
+	// Holding the wager in escrow until the game is settled, so a tie can be refunded if the bot stops while the
+	// player decides
+	escrowID, err := dba.EscrowChips(&game.Player, wager)
+	if err != nil {
+		warGamesMu.Unlock()
+		RespondEphemeral(i, T(locale, "game.not_enough_chips", dba.GetChipTotal(player.Username)))
+		return
+	}
+	game.EscrowIDs = []int64{escrowID}
+
+	tied := game.Compare() == 0
+	if tied {
+		WarGamesMap[player.Username] = game
+	}
+	warGamesMu.Unlock()
+
+	content := game.Content() + "\n"
+	var components []discordgo.MessageComponent
+
+	switch {
+	case tied:
+		content += T(locale, "war.tie")
+		components = game.Components()
+	case game.Compare() > 0:
+		content += T(locale, "war.win") + game.settle(wager)
+	default:
+		content += T(locale, "war.lose") + game.settle(-wager)
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: components,
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}
+
+// WarButton handles the go to war and surrender buttons on a tie
+func WarButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+
+	warGamesMu.Lock()
+	game, ok := WarGamesMap[i.Member.User.Username]
+	warGamesMu.Unlock()
+
+	// Buttons pressed by other players, or on old games, are ignored
+	if !ok || i.Message.Interaction == nil || i.Message.Interaction.ID != game.InteractionID {
+		RespondEphemeral(i, T(LocaleFor(i), "war.not_yours"))
+		return
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+
+	if game.Over {
+		AcknowledgeInteraction(i)
+		return
+	}
+
+	var message string
+
+	if i.MessageComponentData().CustomID == "war-go" {
+		// Holding the raise in escrow alongside the wager, so a lost war takes it rather than the chips left after
+		// anything the player spent meanwhile
+		raiseID, err := dba.EscrowChips(&game.Player, game.Wager)
+		if err != nil {
+			RespondEphemeral(i, T(game.Locale, "war.raise_short", game.Wager, dba.GetChipTotal(game.Player.Username)))
+			return
+		}
+		game.EscrowIDs = append(game.EscrowIDs, raiseID)
+
+		net := game.GoToWar()
+		if net > 0 {
+			message = T(game.Locale, "war.war_win")
+		} else {
+			message = T(game.Locale, "war.war_lose")
+		}
+		game.Over = true
+		message += game.settle(net)
+	} else {
+		game.Over = true
+		message = T(game.Locale, "war.surrendered") + game.settle(-(game.Wager - game.Wager/2))
+	}
+
+	warGamesMu.Lock()
+	delete(WarGamesMap, game.Player.Username)
+	warGamesMu.Unlock()
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    game.Content() + "\n" + message,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+}